@@ -0,0 +1,315 @@
+package avro
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/BrobridgeOrg/schemer"
+	"github.com/BrobridgeOrg/schemer/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var testRecord = `{
+	"type": "record",
+	"name": "User",
+	"namespace": "com.brobridge",
+	"fields": [
+		{ "name": "id", "type": "long" },
+		{ "name": "name", "type": "string", "doc": "Full name" },
+		{ "name": "nickname", "type": [ "null", "string" ], "default": null },
+		{ "name": "score", "type": "double", "default": 1.5 },
+		{ "name": "avatar", "type": [ "null", "bytes" ] },
+		{ "name": "createdAt", "type": { "type": "long", "logicalType": "timestamp-millis" } },
+		{ "name": "updatedAt", "type": [ "null", { "type": "long", "logicalType": "timestamp-micros" } ] },
+		{ "name": "birthday", "type": { "type": "int", "logicalType": "date" } },
+		{ "name": "status", "type": { "type": "enum", "name": "Status", "symbols": [ "ACTIVE", "INACTIVE" ] } },
+		{ "name": "tags", "type": { "type": "array", "items": "string" } },
+		{ "name": "labels", "type": { "type": "map", "values": "string" } },
+		{
+			"name": "address",
+			"type": {
+				"type": "record",
+				"name": "Address",
+				"fields": [
+					{ "name": "city", "type": "string" },
+					{ "name": "zip", "type": [ "null", "string" ] }
+				]
+			}
+		},
+		{ "name": "billingAddress", "type": [ "null", "Address" ] }
+	]
+}`
+
+func TestUnmarshalJSON(t *testing.T) {
+
+	schema := schemer.NewSchema()
+	err := UnmarshalJSON([]byte(testRecord), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, schemer.TYPE_INT64, schema.Fields["id"].Type)
+	assert.True(t, schema.Fields["id"].NotNull)
+	assert.Equal(t, schemer.TYPE_STRING, schema.Fields["name"].Type)
+	assert.Equal(t, "Full name", schema.Fields["name"].Props["description"])
+	assert.Equal(t, schemer.TYPE_STRING, schema.Fields["nickname"].Type)
+	assert.False(t, schema.Fields["nickname"].NotNull)
+	assert.Equal(t, float64(1.5), schema.Fields["score"].Default)
+	assert.Equal(t, schemer.TYPE_BINARY, schema.Fields["avatar"].Type)
+	assert.Equal(t, schemer.TYPE_TIME, schema.Fields["createdAt"].Type)
+	assert.Equal(t, types.TIME_PRECISION_MILLISECOND, schema.Fields["createdAt"].Info.(*types.Time).Precision)
+	assert.Equal(t, types.TIME_PRECISION_MICROSECOND, schema.Fields["updatedAt"].Info.(*types.Time).Precision)
+	assert.False(t, schema.Fields["updatedAt"].NotNull)
+	assert.Equal(t, schemer.TYPE_DATE, schema.Fields["birthday"].Type)
+	assert.Equal(t, schemer.TYPE_ENUM, schema.Fields["status"].Type)
+	assert.Equal(t, []string{"ACTIVE", "INACTIVE"}, schema.Fields["status"].Info.(*types.Enum).Symbols)
+	assert.Equal(t, schemer.TYPE_ARRAY, schema.Fields["tags"].Type)
+	assert.Equal(t, schemer.TYPE_STRING, schema.Fields["tags"].Subtype.Type)
+	assert.Equal(t, schemer.TYPE_ANY, schema.Fields["labels"].Type)
+
+	address := schema.Fields["address"]
+	assert.Equal(t, schemer.TYPE_MAP, address.Type)
+	assert.True(t, address.NotNull)
+	assert.Equal(t, schemer.TYPE_STRING, address.Schema.Fields["city"].Type)
+	assert.False(t, address.Schema.Fields["zip"].NotNull)
+
+	billingAddress := schema.Fields["billingAddress"]
+	assert.Equal(t, schemer.TYPE_MAP, billingAddress.Type)
+	assert.False(t, billingAddress.NotNull)
+	assert.Equal(t, schemer.TYPE_STRING, billingAddress.Schema.Fields["city"].Type)
+
+	// Timestamp with millisecond precision
+	result := schema.Normalize(map[string]interface{}{
+		"createdAt": int64(1595182568123),
+	})
+	assert.Equal(t, int64(1595182568123), result["createdAt"].(time.Time).UnixMilli())
+}
+
+func TestUnmarshalJSON_Unsupported(t *testing.T) {
+
+	testCases := map[string]error{
+		`{ "type": "string" }`: ErrNotRecord,
+		`{ "type": "record", "name": "A", "fields": [ { "name": "a", "type": [ "null", "string", "long" ] } ] }`: ErrUnsupportedUnion,
+		`{ "type": "record", "name": "A", "fields": [ { "name": "a", "type": "B" } ] }`:                          ErrUnknownNamedType,
+		`{ "type": "record", "name": "A", "fields": [ { "name": "a", "type": [ "null", "A" ] } ] }`:              ErrRecursiveType,
+	}
+
+	for source, expected := range testCases {
+		err := UnmarshalJSON([]byte(source), schemer.NewSchema())
+		assert.True(t, errors.Is(err, expected), source)
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+
+	source := `{
+	"id": { "type": "int", "notNull": true },
+	"name": { "type": "string", "description": "Full name" },
+	"enabled": { "type": "bool", "default": true },
+	"avatar": { "type": "binary" },
+	"createdAt": { "type": "time", "notNull": true },
+	"updatedAt": { "type": "time", "precision": "microsecond" },
+	"price": { "type": "decimal", "precision": 10, "scale": 2, "notNull": true },
+	"tags": {
+		"type": "array",
+		"subtype": "string"
+	},
+	"address": {
+		"type": "map",
+		"notNull": true,
+		"fields": {
+			"city": { "type": "string", "notNull": true }
+		}
+	}
+}`
+
+	schema := schemer.NewSchema()
+	err := schemer.UnmarshalJSON([]byte(source), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	data, err := MarshalJSON(schema, "User")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	expected := `{
+	"type": "record",
+	"name": "User",
+	"fields": [
+		{
+			"name": "address",
+			"type": {
+				"type": "record",
+				"name": "User_address",
+				"fields": [
+					{ "name": "city", "type": "string" }
+				]
+			}
+		},
+		{ "name": "avatar", "type": [ "null", "bytes" ], "default": null },
+		{ "name": "createdAt", "type": { "type": "long", "logicalType": "timestamp-millis" } },
+		{ "name": "enabled", "type": [ "boolean", "null" ], "default": true },
+		{ "name": "id", "type": "long" },
+		{ "name": "name", "type": [ "null", "string" ], "default": null, "doc": "Full name" },
+		{ "name": "price", "type": { "type": "bytes", "logicalType": "decimal", "precision": 10, "scale": 2 } },
+		{ "name": "tags", "type": [ "null", { "type": "array", "items": [ "null", "string" ] } ], "default": null },
+		{ "name": "updatedAt", "type": [ "null", { "type": "long", "logicalType": "timestamp-micros" } ], "default": null }
+	]
+}`
+
+	assert.JSONEq(t, expected, string(data))
+
+	// Round trip
+	restored := schemer.NewSchema()
+	err = UnmarshalJSON(data, restored)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, schemer.TYPE_MAP, restored.Fields["address"].Type)
+	assert.True(t, restored.Fields["address"].NotNull)
+	assert.Equal(t, types.TIME_PRECISION_MICROSECOND, restored.Fields["updatedAt"].Info.(*types.Time).Precision)
+	assert.Equal(t, true, restored.Fields["enabled"].Default)
+	assert.Equal(t, schemer.TYPE_DECIMAL, restored.Fields["price"].Type)
+	assert.Equal(t, int32(2), restored.Fields["price"].Info.(*types.Decimal).Scale)
+
+	// Unsupported type
+	schema = schemer.NewSchema()
+	schema.Fields["attached"] = schemer.NewDefinition(schemer.TYPE_ANY)
+	_, err = Marshal(schema, "User")
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+
+	// Values above MaxInt64 cannot be stored in long
+	schema = schemer.NewSchema()
+	schema.Fields["counter"] = schemer.NewDefinition(schemer.TYPE_UINT64)
+	_, err = Marshal(schema, "User")
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+}
+
+func TestMarshalJSON_DateAndTimeOfDay(t *testing.T) {
+
+	source := `{
+	"birthday": { "type": "date", "notNull": true },
+	"opensAt": { "type": "timeofday", "notNull": true },
+	"closesAt": { "type": "timeofday", "precision": "microsecond", "notNull": true }
+}`
+
+	schema := schemer.NewSchema()
+	err := schemer.UnmarshalJSON([]byte(source), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	data, err := MarshalJSON(schema, "Store")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// Round trip
+	restored := schemer.NewSchema()
+	err = UnmarshalJSON(data, restored)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, schemer.TYPE_DATE, restored.Fields["birthday"].Type)
+	assert.True(t, restored.Fields["birthday"].NotNull)
+	assert.Equal(t, schemer.TYPE_TIMEOFDAY, restored.Fields["opensAt"].Type)
+	assert.Equal(t, types.TIME_PRECISION_MILLISECOND, restored.Fields["opensAt"].Info.(*types.Time).Precision)
+	assert.Equal(t, schemer.TYPE_TIMEOFDAY, restored.Fields["closesAt"].Type)
+	assert.Equal(t, types.TIME_PRECISION_MICROSECOND, restored.Fields["closesAt"].Info.(*types.Time).Precision)
+
+	// Same document is generated again
+	regenerated, err := MarshalJSON(restored, "Store")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.JSONEq(t, string(data), string(regenerated))
+}
+
+func TestMarshalJSON_Enum(t *testing.T) {
+
+	schema := schemer.NewSchema()
+	err := schemer.UnmarshalJSON([]byte(`{
+	"status": { "type": "enum", "symbols": [ "ACTIVE", "INACTIVE" ], "notNull": true }
+}`), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	data, err := MarshalJSON(schema, "User")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// Round trip keeps enum type
+	restored := schemer.NewSchema()
+	err = UnmarshalJSON(data, restored)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	status := restored.Fields["status"]
+	assert.Equal(t, schemer.TYPE_ENUM, status.Type)
+	assert.True(t, status.NotNull)
+	assert.Equal(t, []string{"ACTIVE", "INACTIVE"}, status.Info.(*types.Enum).Symbols)
+}
+
+func TestMarshalJSON_Refs(t *testing.T) {
+
+	source := `{
+	"$defs": {
+		"node": {
+			"type": "map",
+			"fields": {
+				"name": { "type": "string", "notNull": true },
+				"children": { "type": "array", "subtype": { "$ref": "#/$defs/node", "notNull": true } }
+			}
+		}
+	},
+	"tree": { "$ref": "#/$defs/node", "notNull": true }
+}`
+
+	schema := schemer.NewSchema()
+	err := schemer.UnmarshalJSON([]byte(source), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	doc, err := Marshal(schema, "Record")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// Recursive record is referred by name
+	tree := doc["fields"].([]interface{})[0].(map[string]interface{})
+	node := tree["type"].(map[string]interface{})
+	assert.Equal(t, "node", node["name"])
+
+	children := node["fields"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "children", children["name"])
+	assert.Equal(t, []interface{}{
+		"null",
+		map[string]interface{}{
+			"type":  "array",
+			"items": "node",
+		},
+	}, children["type"])
+
+	// Recursive array cannot be represented
+	schema = schemer.NewSchema()
+	err = schemer.UnmarshalJSON([]byte(`{
+	"$defs": { "list": { "type": "array", "subtype": { "$ref": "#/$defs/list" } } },
+	"items": { "$ref": "#/$defs/list" }
+}`), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	_, err = Marshal(schema, "Record")
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+}
@@ -0,0 +1,296 @@
+package avro
+
+import (
+	"sort"
+	"time"
+
+	"github.com/BrobridgeOrg/schemer"
+	"github.com/BrobridgeOrg/schemer/types"
+)
+
+// Types which can be mapped to Avro primitive types directly.
+// uint64 is not supported because long of Avro cannot hold values above MaxInt64.
+var avroTypes = map[schemer.ValueType]string{
+	schemer.TYPE_BOOLEAN: "boolean",
+	schemer.TYPE_INT64:   "long",
+	schemer.TYPE_FLOAT64: "double",
+	schemer.TYPE_INT8:    "int",
+	schemer.TYPE_INT16:   "int",
+	schemer.TYPE_INT32:   "int",
+	schemer.TYPE_UINT8:   "int",
+	schemer.TYPE_UINT16:  "int",
+	schemer.TYPE_UINT32:  "long",
+	schemer.TYPE_FLOAT32: "float",
+	schemer.TYPE_STRING:  "string",
+	schemer.TYPE_BINARY:  "bytes",
+}
+
+// MarshalJSON generates Avro record schema from schema
+func MarshalJSON(s *schemer.Schema, name string) ([]byte, error) {
+
+	doc, err := Marshal(s, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(doc)
+}
+
+// Marshal converts schema to Avro record schema with specific name
+func Marshal(s *schemer.Schema, name string) (map[string]interface{}, error) {
+	return convertSchema(name, s, make(map[string]bool))
+}
+
+// convertSchema converts schema to record. Named records of shared definitions which have been defined are kept in named.
+func convertSchema(name string, s *schemer.Schema, named map[string]bool) (map[string]interface{}, error) {
+
+	if s == nil {
+		s = schemer.NewSchema()
+	}
+
+	names := make([]string, 0, len(s.Fields))
+	for fieldName := range s.Fields {
+
+		// Skip internal fields
+		if len(fieldName) > 0 && fieldName[0] == '$' {
+			continue
+		}
+
+		names = append(names, fieldName)
+	}
+
+	sort.Strings(names)
+
+	fields := make([]interface{}, len(names))
+	for i, fieldName := range names {
+
+		field, err := convertField(name, fieldName, s.Fields[fieldName], named)
+		if err != nil {
+			return nil, err
+		}
+
+		fields[i] = field
+	}
+
+	return map[string]interface{}{
+		"type":   "record",
+		"name":   name,
+		"fields": fields,
+	}, nil
+}
+
+func convertField(recordName string, name string, def *schemer.Definition, named map[string]bool) (map[string]interface{}, error) {
+
+	t, err := convertType(recordName+"_"+name, def, named)
+	if err != nil {
+		return nil, err
+	}
+
+	field := map[string]interface{}{
+		"name": name,
+	}
+
+	if v, ok := def.Props["description"]; ok {
+		field["doc"] = v
+	}
+
+	if def.NotNull {
+		field["type"] = t
+
+		if def.Default != nil {
+			field["default"] = getDefaultValue(def)
+		}
+
+		return field, nil
+	}
+
+	// Default value of union must match the first type
+	if def.Default != nil {
+		field["type"] = []interface{}{t, "null"}
+		field["default"] = getDefaultValue(def)
+	} else {
+		field["type"] = []interface{}{"null", t}
+		field["default"] = nil
+	}
+
+	return field, nil
+}
+
+func getDefaultValue(def *schemer.Definition) interface{} {
+
+	switch d := def.Default.(type) {
+	case time.Time:
+		if def.Info.(*types.Time).Precision == types.TIME_PRECISION_MICROSECOND {
+			return d.UnixMicro()
+		}
+
+		return d.UnixMilli()
+	case types.DecimalValue:
+		return bytesToString(d.Bytes())
+	case types.UUID:
+		return d.String()
+	case types.EnumValue:
+		return d.Symbol
+	case types.Date:
+		return d.EpochDays()
+	case types.TimeOfDay:
+		if def.Info.(*types.Time).Precision == types.TIME_PRECISION_MICROSECOND {
+			return d.Duration().Microseconds()
+		}
+
+		return d.Duration().Milliseconds()
+	case []byte:
+		return bytesToString(d)
+	}
+
+	return def.Default
+}
+
+// bytesToString encodes bytes as ISO-8859-1 string which is used by Avro JSON
+func bytesToString(data []byte) string {
+
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+
+	return string(runes)
+}
+
+func convertType(name string, def *schemer.Definition, named map[string]bool) (interface{}, error) {
+
+	if len(def.Ref) > 0 {
+		return convertRefType(name, def, named)
+	}
+
+	if t, ok := avroTypes[def.Type]; ok {
+		return t, nil
+	}
+
+	switch def.Type {
+	case schemer.TYPE_TIME:
+
+		logicalType := "timestamp-millis"
+		if t, ok := def.Info.(*types.Time); ok && t.Precision == types.TIME_PRECISION_MICROSECOND {
+			logicalType = "timestamp-micros"
+		}
+
+		return map[string]interface{}{
+			"type":        "long",
+			"logicalType": logicalType,
+		}, nil
+
+	case schemer.TYPE_DATE:
+		return map[string]interface{}{
+			"type":        "int",
+			"logicalType": "date",
+		}, nil
+
+	case schemer.TYPE_TIMEOFDAY:
+
+		if t, ok := def.Info.(*types.Time); ok && t.Precision == types.TIME_PRECISION_MICROSECOND {
+			return map[string]interface{}{
+				"type":        "long",
+				"logicalType": "time-micros",
+			}, nil
+		}
+
+		return map[string]interface{}{
+			"type":        "int",
+			"logicalType": "time-millis",
+		}, nil
+
+	case schemer.TYPE_ENUM:
+
+		e, ok := def.Info.(*types.Enum)
+		if !ok {
+			return nil, wrapError(name, ErrUnsupportedType, def.Type)
+		}
+
+		return map[string]interface{}{
+			"type":    "enum",
+			"name":    name,
+			"symbols": e.Symbols,
+		}, nil
+
+	case schemer.TYPE_UUID:
+		return map[string]interface{}{
+			"type":        "string",
+			"logicalType": "uuid",
+		}, nil
+
+	case schemer.TYPE_DECIMAL:
+
+		// Precision is required by Avro decimal
+		d, ok := def.Info.(*types.Decimal)
+		if !ok || d.Precision == 0 {
+			return nil, wrapError(name, ErrUnsupportedType, "decimal without precision")
+		}
+
+		scale := d.Scale
+		if scale < 0 {
+			scale = 0
+		}
+
+		return map[string]interface{}{
+			"type":        "bytes",
+			"logicalType": "decimal",
+			"precision":   d.Precision,
+			"scale":       scale,
+		}, nil
+
+	case schemer.TYPE_ARRAY:
+
+		if def.Subtype == nil {
+			return nil, wrapError(name, ErrUnsupportedType, def.Type)
+		}
+
+		items, err := convertType(name, def.Subtype, named)
+		if err != nil {
+			return nil, err
+		}
+
+		if !def.Subtype.NotNull {
+			items = []interface{}{"null", items}
+		}
+
+		return map[string]interface{}{
+			"type":  "array",
+			"items": items,
+		}, nil
+
+	case schemer.TYPE_MAP:
+		return convertSchema(name, def.Schema, named)
+	}
+
+	return nil, wrapError(name, ErrUnsupportedType, def.Type)
+}
+
+// convertRefType converts shared map definition to named record, which is referred by name after defined
+func convertRefType(name string, def *schemer.Definition, named map[string]bool) (interface{}, error) {
+
+	if named[def.Ref] {
+
+		// Only records can be recursive
+		if def.Type != schemer.TYPE_MAP {
+			return nil, wrapError(name, ErrUnsupportedType, "recursive "+def.Type.String())
+		}
+
+		return def.Ref, nil
+	}
+
+	named[def.Ref] = true
+
+	body := *def
+	body.Ref = ""
+
+	if def.Type == schemer.TYPE_MAP {
+		return convertType(def.Ref, &body, named)
+	}
+
+	// Other types are inlined
+	t, err := convertType(name, &body, named)
+	delete(named, def.Ref)
+
+	return t, err
+}
@@ -0,0 +1,416 @@
+package avro
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/BrobridgeOrg/schemer"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+var (
+	ErrInvalidSchema       = errors.New("Invalid Avro schema")
+	ErrNotRecord           = errors.New("Avro schema is not a record")
+	ErrUnsupportedType     = errors.New("Unsupported Avro type")
+	ErrUnsupportedUnion    = errors.New("Unsupported Avro union")
+	ErrUnknownNamedType    = errors.New("Unknown Avro named type")
+	ErrInvalidFieldsSchema = errors.New("Invalid Avro fields")
+	ErrRecursiveType       = errors.New("Recursive Avro type is not supported")
+)
+
+var primitiveTypes = map[string]string{
+	"boolean": "bool",
+	"int":     "int",
+	"long":    "int",
+	"float":   "float",
+	"double":  "float",
+	"bytes":   "binary",
+	"string":  "string",
+}
+
+var timestampPrecisions = map[string]string{
+	"timestamp-millis":       "millisecond",
+	"timestamp-micros":       "microsecond",
+	"local-timestamp-millis": "millisecond",
+	"local-timestamp-micros": "microsecond",
+}
+
+// Underlying types and precisions of time of day
+var timeOfDayPrecisions = map[string][2]string{
+	"time-millis": {"int", "millisecond"},
+	"time-micros": {"long", "microsecond"},
+}
+
+type parser struct {
+	namedTypes map[string]map[string]interface{}
+	pending    map[string]bool
+}
+
+// UnmarshalJSON parses Avro record schema and stores fields into schema
+func UnmarshalJSON(source []byte, s *schemer.Schema) error {
+
+	var doc interface{}
+	err := json.Unmarshal(source, &doc)
+	if err != nil {
+		return err
+	}
+
+	return Unmarshal(doc, s)
+}
+
+// Unmarshal converts Avro record schema to schema
+func Unmarshal(doc interface{}, s *schemer.Schema) error {
+
+	record, ok := doc.(map[string]interface{})
+	if !ok || record["type"] != "record" {
+		return ErrNotRecord
+	}
+
+	p := &parser{
+		namedTypes: make(map[string]map[string]interface{}),
+		pending:    make(map[string]bool),
+	}
+
+	def, err := p.convertRecord("", record)
+	if err != nil {
+		return err
+	}
+
+	return schemer.Unmarshal(def["fields"].(map[string]interface{}), s)
+}
+
+func wrapError(path string, err error, v interface{}) error {
+
+	if len(path) == 0 {
+		return fmt.Errorf("%w: %v", err, v)
+	}
+
+	return fmt.Errorf("%w: %v (%s)", err, v, path)
+}
+
+func joinPath(parent string, key string) string {
+
+	if len(parent) == 0 {
+		return key
+	}
+
+	return parent + "." + key
+}
+
+func getFullName(namespace string, doc map[string]interface{}) string {
+
+	name, _ := doc["name"].(string)
+	if ns, ok := doc["namespace"].(string); ok {
+		namespace = ns
+	}
+
+	if len(namespace) == 0 || strings.Contains(name, ".") {
+		return name
+	}
+
+	return namespace + "." + name
+}
+
+func getNamespace(fullName string) string {
+
+	idx := strings.LastIndex(fullName, ".")
+	if idx == -1 {
+		return ""
+	}
+
+	return fullName[:idx]
+}
+
+func (p *parser) register(namespace string, doc map[string]interface{}, def map[string]interface{}) string {
+
+	fullName := getFullName(namespace, doc)
+	p.namedTypes[fullName] = def
+
+	// Short name can be used in the same namespace
+	if name, ok := doc["name"].(string); ok {
+		if _, ok := p.namedTypes[name]; !ok {
+			p.namedTypes[name] = def
+		}
+	}
+
+	return fullName
+}
+
+func (p *parser) convertRecord(namespace string, doc map[string]interface{}) (map[string]interface{}, error) {
+
+	fieldList, ok := doc["fields"].([]interface{})
+	if !ok {
+		return nil, wrapError(namespace, ErrInvalidFieldsSchema, doc["name"])
+	}
+
+	fields := make(map[string]interface{}, len(fieldList))
+	def := map[string]interface{}{
+		"type":   "map",
+		"fields": fields,
+	}
+
+	// Register before parsing fields for referencing
+	fullName := p.register(namespace, doc, def)
+	ns := getNamespace(fullName)
+
+	p.pending[fullName] = true
+	defer delete(p.pending, fullName)
+
+	for _, f := range fieldList {
+
+		field, ok := f.(map[string]interface{})
+		if !ok {
+			return nil, wrapError(fullName, ErrInvalidFieldsSchema, f)
+		}
+
+		name, ok := field["name"].(string)
+		if !ok {
+			return nil, wrapError(fullName, ErrInvalidFieldsSchema, field["name"])
+		}
+
+		fieldDef, err := p.convertField(ns, joinPath(fullName, name), field)
+		if err != nil {
+			return nil, err
+		}
+
+		fields[name] = fieldDef
+	}
+
+	return def, nil
+}
+
+func (p *parser) convertField(namespace string, path string, field map[string]interface{}) (map[string]interface{}, error) {
+
+	def, err := p.convertType(namespace, path, field["type"])
+	if err != nil {
+		return nil, err
+	}
+
+	// Named types are shared, so field properties are applied to a copy
+	fieldDef := make(map[string]interface{}, len(def)+2)
+	for key, value := range def {
+		fieldDef[key] = value
+	}
+
+	if v, ok := field["default"]; ok && v != nil {
+		fieldDef["default"] = v
+	}
+
+	if v, ok := field["doc"]; ok {
+		fieldDef["description"] = v
+	}
+
+	return fieldDef, nil
+}
+
+func (p *parser) convertType(namespace string, path string, t interface{}) (map[string]interface{}, error) {
+
+	switch d := t.(type) {
+	case string:
+
+		if name, ok := primitiveTypes[d]; ok {
+			return map[string]interface{}{
+				"type":    name,
+				"notNull": true,
+			}, nil
+		}
+
+		if d == "null" {
+			return nil, wrapError(path, ErrUnsupportedType, d)
+		}
+
+		// Reference to named type
+		def, ok := p.namedTypes[d]
+		if !ok {
+			def, ok = p.namedTypes[namespace+"."+d]
+		}
+
+		if !ok {
+			return nil, wrapError(path, ErrUnknownNamedType, d)
+		}
+
+		if p.pending[d] || p.pending[namespace+"."+d] {
+			return nil, wrapError(path, ErrRecursiveType, d)
+		}
+
+		return def, nil
+
+	case []interface{}:
+		return p.convertUnion(namespace, path, d)
+
+	case map[string]interface{}:
+		return p.convertComplexType(namespace, path, d)
+	}
+
+	return nil, wrapError(path, ErrInvalidSchema, t)
+}
+
+func (p *parser) convertUnion(namespace string, path string, union []interface{}) (map[string]interface{}, error) {
+
+	nullable := false
+	var branch interface{}
+	for _, t := range union {
+
+		if t == "null" {
+			nullable = true
+			continue
+		}
+
+		// Only union of a type and null can be represented
+		if branch != nil {
+			return nil, wrapError(path, ErrUnsupportedUnion, union)
+		}
+
+		branch = t
+	}
+
+	if branch == nil {
+		return nil, wrapError(path, ErrUnsupportedUnion, union)
+	}
+
+	def, err := p.convertType(namespace, path, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	if !nullable {
+		return def, nil
+	}
+
+	nullableDef := make(map[string]interface{}, len(def))
+	for key, value := range def {
+		nullableDef[key] = value
+	}
+
+	delete(nullableDef, "notNull")
+
+	return nullableDef, nil
+}
+
+func (p *parser) convertComplexType(namespace string, path string, doc map[string]interface{}) (map[string]interface{}, error) {
+
+	t, _ := doc["type"].(string)
+
+	// Logical types
+	if logicalType, ok := doc["logicalType"].(string); ok {
+		if precision, ok := timestampPrecisions[logicalType]; ok && t == "long" {
+			return map[string]interface{}{
+				"type":      "time",
+				"precision": precision,
+				"notNull":   true,
+			}, nil
+		}
+
+		if tod, ok := timeOfDayPrecisions[logicalType]; ok && t == tod[0] {
+			return map[string]interface{}{
+				"type":      "timeofday",
+				"precision": tod[1],
+				"notNull":   true,
+			}, nil
+		}
+
+		if logicalType == "date" && t == "int" {
+			return map[string]interface{}{
+				"type":    "date",
+				"notNull": true,
+			}, nil
+		}
+
+		if logicalType == "uuid" && t == "string" {
+			return map[string]interface{}{
+				"type":    "uuid",
+				"notNull": true,
+			}, nil
+		}
+
+		if logicalType == "decimal" && (t == "bytes" || t == "fixed") {
+
+			def := map[string]interface{}{
+				"type":    "decimal",
+				"notNull": true,
+			}
+
+			if precision, ok := doc["precision"]; ok {
+				def["precision"] = precision
+			}
+
+			scale, ok := doc["scale"]
+			if !ok {
+				scale = 0
+			}
+
+			def["scale"] = scale
+
+			return def, nil
+		}
+
+		// Unknown logical types fall back to underlying type
+	}
+
+	switch t {
+	case "record", "error":
+		def, err := p.convertRecord(namespace, doc)
+		if err != nil {
+			return nil, err
+		}
+
+		def["notNull"] = true
+
+		return def, nil
+
+	case "enum":
+
+		def := map[string]interface{}{
+			"type":    "enum",
+			"notNull": true,
+		}
+
+		if symbols, ok := doc["symbols"].([]interface{}); ok {
+			def["symbols"] = symbols
+		}
+
+		p.register(namespace, doc, def)
+
+		return def, nil
+
+	case "fixed":
+
+		def := map[string]interface{}{
+			"type":    "binary",
+			"notNull": true,
+		}
+
+		p.register(namespace, doc, def)
+
+		return def, nil
+
+	case "array":
+
+		subtype, err := p.convertType(namespace, path+"[]", doc["items"])
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]interface{}{
+			"type":    "array",
+			"subtype": subtype,
+			"notNull": true,
+		}, nil
+
+	case "map":
+		// Keys of Avro map are dynamic
+		return map[string]interface{}{
+			"type":    "any",
+			"notNull": true,
+		}, nil
+	}
+
+	if _, ok := doc["type"].(string); ok {
+		return p.convertType(namespace, path, doc["type"])
+	}
+
+	return nil, wrapError(path, ErrInvalidSchema, doc["type"])
+}
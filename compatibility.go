@@ -0,0 +1,356 @@
+package schemer
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/BrobridgeOrg/schemer/types"
+)
+
+type CompatibilityMode int32
+
+const (
+	COMPATIBILITY_NONE     CompatibilityMode = 0
+	COMPATIBILITY_BACKWARD CompatibilityMode = 1
+	COMPATIBILITY_FORWARD  CompatibilityMode = 2
+	COMPATIBILITY_FULL     CompatibilityMode = 3
+)
+
+var CompatibilityModes = map[string]CompatibilityMode{
+	"none":     COMPATIBILITY_NONE,
+	"backward": COMPATIBILITY_BACKWARD,
+	"forward":  COMPATIBILITY_FORWARD,
+	"full":     COMPATIBILITY_FULL,
+}
+
+func (m CompatibilityMode) String() string {
+
+	for name, mode := range CompatibilityModes {
+		if mode == m {
+			return name
+		}
+	}
+
+	return "unknown"
+}
+
+type IssueKind string
+
+const (
+	ISSUE_FIELD_REMOVED   IssueKind = "field_removed"
+	ISSUE_TYPE_CHANGED    IssueKind = "type_changed"
+	ISSUE_SUBTYPE_CHANGED IssueKind = "subtype_changed"
+	ISSUE_MISSING_DEFAULT IssueKind = "missing_default"
+	ISSUE_NOT_NULL        IssueKind = "not_null"
+	ISSUE_SYMBOL_REMOVED  IssueKind = "symbol_removed"
+	ISSUE_PRECISION       IssueKind = "precision_changed"
+	ISSUE_UNION_CHANGED   IssueKind = "union_changed"
+)
+
+// CompatibilityIssue describes a change which breaks compatibility in specific direction.
+// Mode is either backward (new schema reads old records) or forward (old schema reads new records).
+type CompatibilityIssue struct {
+	Path string
+	Kind IssueKind
+	Mode CompatibilityMode
+	Old  *Definition
+	New  *Definition
+}
+
+func (i *CompatibilityIssue) Error() string {
+	return fmt.Sprintf("%s: %s (%s)", i.Path, i.Kind, i.Mode)
+}
+
+// CheckCompatibility compares new schema with old schema and returns all issues under specific mode
+func CheckCompatibility(oldSchema *Schema, newSchema *Schema, mode CompatibilityMode) []*CompatibilityIssue {
+
+	var issues []*CompatibilityIssue
+
+	if mode == COMPATIBILITY_BACKWARD || mode == COMPATIBILITY_FULL {
+		// New schema should be able to read old records
+		c := &compatibilityChecker{
+			mode: COMPATIBILITY_BACKWARD,
+		}
+
+		c.checkSchema("", newSchema, oldSchema)
+		issues = append(issues, c.issues...)
+	}
+
+	if mode == COMPATIBILITY_FORWARD || mode == COMPATIBILITY_FULL {
+		// Old schema should be able to read new records
+		c := &compatibilityChecker{
+			mode: COMPATIBILITY_FORWARD,
+		}
+
+		c.checkSchema("", oldSchema, newSchema)
+		issues = append(issues, c.issues...)
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Path < issues[j].Path
+	})
+
+	return issues
+}
+
+// IsCompatible returns true if there is no issue under specific mode
+func IsCompatible(oldSchema *Schema, newSchema *Schema, mode CompatibilityMode) bool {
+	return len(CheckCompatibility(oldSchema, newSchema, mode)) == 0
+}
+
+type compatibilityChecker struct {
+	mode   CompatibilityMode
+	issues []*CompatibilityIssue
+
+	// Pairs of shared definitions which have been checked
+	visited map[[2]*Definition]bool
+}
+
+func (c *compatibilityChecker) addIssue(path string, kind IssueKind, reader *Definition, writer *Definition) {
+
+	issue := &CompatibilityIssue{
+		Path: path,
+		Kind: kind,
+		Mode: c.mode,
+	}
+
+	if c.mode == COMPATIBILITY_BACKWARD {
+		issue.Old = writer
+		issue.New = reader
+	} else {
+		issue.Old = reader
+		issue.New = writer
+	}
+
+	c.issues = append(c.issues, issue)
+}
+
+func (c *compatibilityChecker) checkSchema(path string, reader *Schema, writer *Schema) {
+
+	if reader == nil || writer == nil {
+		return
+	}
+
+	for name, readerDef := range reader.Fields {
+
+		fieldPath := joinPath(path, name)
+
+		writerDef, ok := writer.Fields[name]
+		if !ok {
+
+			// Reader is able to use null or default value for missing field
+			if !readerDef.NotNull || readerDef.Default != nil {
+				continue
+			}
+
+			if c.mode == COMPATIBILITY_FORWARD {
+				// Old consumers cannot get this field anymore
+				c.addIssue(fieldPath, ISSUE_FIELD_REMOVED, readerDef, nil)
+				continue
+			}
+
+			c.addIssue(fieldPath, ISSUE_MISSING_DEFAULT, readerDef, nil)
+			continue
+		}
+
+		c.checkDefinition(fieldPath, ISSUE_TYPE_CHANGED, readerDef, writerDef)
+	}
+}
+
+func (c *compatibilityChecker) checkDefinition(path string, kind IssueKind, reader *Definition, writer *Definition) {
+
+	if !isReadable(reader.Type, writer.Type) {
+		c.addIssue(path, kind, reader, writer)
+		return
+	}
+
+	// Writer might produce null values
+	if reader.NotNull && !writer.NotNull && reader.Default == nil {
+		c.addIssue(path, ISSUE_NOT_NULL, reader, writer)
+	}
+
+	if reader.Type != writer.Type {
+		return
+	}
+
+	// Shared definitions might be recursive
+	if len(reader.Ref) > 0 || len(writer.Ref) > 0 {
+
+		pair := [2]*Definition{reader, writer}
+		if c.visited[pair] {
+			return
+		}
+
+		if c.visited == nil {
+			c.visited = make(map[[2]*Definition]bool)
+		}
+
+		c.visited[pair] = true
+	}
+
+	switch reader.Type {
+	case TYPE_MAP:
+		c.checkSchema(path, reader.Schema, writer.Schema)
+	case TYPE_ARRAY:
+		if reader.Subtype != nil && writer.Subtype != nil {
+			c.checkDefinition(path+"[]", ISSUE_SUBTYPE_CHANGED, reader.Subtype, writer.Subtype)
+		}
+	case TYPE_ENUM:
+		c.checkEnum(path, reader, writer)
+	case TYPE_DECIMAL:
+		c.checkDecimal(path, reader, writer)
+	case TYPE_UNION:
+		c.checkUnion(path, reader, writer)
+	}
+}
+
+// checkEnum requires reader to have all symbols of writer
+func (c *compatibilityChecker) checkEnum(path string, reader *Definition, writer *Definition) {
+
+	readerEnum, _ := reader.Info.(*types.Enum)
+	writerEnum, _ := writer.Info.(*types.Enum)
+	if readerEnum == nil || writerEnum == nil {
+		return
+	}
+
+	symbols := make(map[string]bool, len(readerEnum.Symbols))
+	for _, symbol := range readerEnum.Symbols {
+		symbols[symbol] = true
+	}
+
+	for _, symbol := range writerEnum.Symbols {
+		if !symbols[symbol] {
+			c.addIssue(path, ISSUE_SYMBOL_REMOVED, reader, writer)
+			return
+		}
+	}
+}
+
+// checkDecimal requires reader to have enough integer digits and scale for values of writer
+func (c *compatibilityChecker) checkDecimal(path string, reader *Definition, writer *Definition) {
+
+	readerDecimal, _ := reader.Info.(*types.Decimal)
+	writerDecimal, _ := writer.Info.(*types.Decimal)
+	if readerDecimal == nil || writerDecimal == nil {
+		return
+	}
+
+	// Scale of -1 means unlimited
+	if readerDecimal.Scale >= 0 && (writerDecimal.Scale < 0 || readerDecimal.Scale < writerDecimal.Scale) {
+		c.addIssue(path, ISSUE_PRECISION, reader, writer)
+		return
+	}
+
+	// Precision of 0 means unlimited
+	if readerDecimal.Precision == 0 {
+		return
+	}
+
+	if writerDecimal.Precision == 0 ||
+		readerDecimal.Precision-getDecimalScale(readerDecimal) < writerDecimal.Precision-getDecimalScale(writerDecimal) {
+		c.addIssue(path, ISSUE_PRECISION, reader, writer)
+	}
+}
+
+// getDecimalScale returns scale of decimal, unlimited scale doesn't take digits from precision
+func getDecimalScale(d *types.Decimal) int32 {
+
+	if d.Scale < 0 {
+		return 0
+	}
+
+	return d.Scale
+}
+
+// checkUnion requires every alternative of writer to be readable by an alternative of reader
+func (c *compatibilityChecker) checkUnion(path string, reader *Definition, writer *Definition) {
+
+	if reader.Discriminator != writer.Discriminator {
+		c.addIssue(path, ISSUE_UNION_CHANGED, reader, writer)
+		return
+	}
+
+	// Alternatives are selected by discriminator
+	if writer.Discriminator != "" {
+
+		keys := make([]string, 0, len(writer.Mapping))
+		for key := range writer.Mapping {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+
+			readerBranch, ok := reader.Mapping[key]
+			if !ok {
+				c.addIssue(path, ISSUE_UNION_CHANGED, reader, writer)
+				continue
+			}
+
+			c.checkDefinition(path, ISSUE_TYPE_CHANGED, readerBranch, writer.Mapping[key])
+		}
+
+		return
+	}
+
+	for _, writerBranch := range writer.Types {
+
+		readerBranch := findReadableBranch(reader, writerBranch)
+		if readerBranch == nil {
+			c.addIssue(path, ISSUE_UNION_CHANGED, reader, writer)
+			return
+		}
+
+		if readerBranch.Type == writerBranch.Type {
+			c.checkDefinition(path, ISSUE_UNION_CHANGED, readerBranch, writerBranch)
+		}
+	}
+}
+
+// findReadableBranch returns alternative of union which is able to read values of writer, the one with the same type is preferred
+func findReadableBranch(union *Definition, writer *Definition) *Definition {
+
+	for _, def := range union.Types {
+		if def.Type == writer.Type {
+			return def
+		}
+	}
+
+	for _, def := range union.Types {
+		if isReadable(def.Type, writer.Type) {
+			return def
+		}
+	}
+
+	return nil
+}
+
+// isReadable returns true if values of writer type can be converted to reader type without loss
+func isReadable(reader ValueType, writer ValueType) bool {
+
+	if reader == writer || reader == TYPE_ANY {
+		return true
+	}
+
+	switch reader {
+	case TYPE_FLOAT64:
+		return writer == TYPE_FLOAT32 || isSignedIntegerType(writer) || isUnsignedIntegerType(writer)
+	case TYPE_DECIMAL:
+		return isSignedIntegerType(writer) || isUnsignedIntegerType(writer)
+	case TYPE_FLOAT32:
+		// Integers up to 16 bits can be represented exactly
+		return isWiderIntegerType(TYPE_INT32, writer) && integerBitSizes[writer] <= 16
+	case TYPE_INT8, TYPE_INT16, TYPE_INT32, TYPE_INT64, TYPE_UINT8, TYPE_UINT16, TYPE_UINT32, TYPE_UINT64:
+		return isWiderIntegerType(reader, writer)
+	case TYPE_STRING:
+		// Alternative of union might be a map or an array
+		return writer != TYPE_UNION && isScalarType(writer)
+	case TYPE_BINARY:
+		return writer == TYPE_STRING || writer == TYPE_UUID
+	case TYPE_TIME:
+		return writer == TYPE_DATE
+	}
+
+	return false
+}
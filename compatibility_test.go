@@ -0,0 +1,235 @@
+package schemer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckCompatibility(t *testing.T) {
+
+	oldSchema := NewSchema()
+	err := UnmarshalJSON([]byte(`{
+	"id": { "type": "int", "notNull": true },
+	"name": { "type": "string", "notNull": true },
+	"nickname": { "type": "string" },
+	"group": { "type": "string", "notNull": true, "default": "users" },
+	"code": { "type": "string" },
+	"score": { "type": "int" },
+	"tags": { "type": "array", "subtype": "string" },
+	"attributes": {
+		"type": "map",
+		"fields": {
+			"title": { "type": "string" }
+		}
+	}
+}`), oldSchema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	newSchema := NewSchema()
+	err = UnmarshalJSON([]byte(`{
+	"id": { "type": "int", "notNull": true },
+	"code": { "type": "int" },
+	"score": { "type": "float" },
+	"level": { "type": "int", "notNull": true },
+	"region": { "type": "string", "notNull": true, "default": "tw" },
+	"tags": { "type": "array", "subtype": "int" },
+	"attributes": {
+		"type": "map",
+		"fields": {
+			"title": { "type": "string", "notNull": true }
+		}
+	}
+}`), newSchema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// New schema reads old records
+	issues := CheckCompatibility(oldSchema, newSchema, COMPATIBILITY_BACKWARD)
+	if !assert.Len(t, issues, 4) {
+		return
+	}
+
+	assert.Equal(t, "attributes.title", issues[0].Path)
+	assert.Equal(t, ISSUE_NOT_NULL, issues[0].Kind)
+	assert.Equal(t, "code", issues[1].Path)
+	assert.Equal(t, ISSUE_TYPE_CHANGED, issues[1].Kind)
+	assert.Equal(t, TYPE_STRING, issues[1].Old.Type)
+	assert.Equal(t, TYPE_INT64, issues[1].New.Type)
+	assert.Equal(t, "level", issues[2].Path)
+	assert.Equal(t, ISSUE_MISSING_DEFAULT, issues[2].Kind)
+	assert.Equal(t, "tags[]", issues[3].Path)
+	assert.Equal(t, ISSUE_SUBTYPE_CHANGED, issues[3].Kind)
+
+	for _, issue := range issues {
+		assert.Equal(t, COMPATIBILITY_BACKWARD, issue.Mode)
+	}
+
+	// Old schema reads new records, removed fields which are nullable or have default are fine
+	issues = CheckCompatibility(oldSchema, newSchema, COMPATIBILITY_FORWARD)
+	if !assert.Len(t, issues, 2) {
+		return
+	}
+
+	assert.Equal(t, "name", issues[0].Path)
+	assert.Equal(t, ISSUE_FIELD_REMOVED, issues[0].Kind)
+	assert.Equal(t, TYPE_STRING, issues[0].Old.Type)
+	assert.Nil(t, issues[0].New)
+	assert.Equal(t, "score", issues[1].Path)
+	assert.Equal(t, ISSUE_TYPE_CHANGED, issues[1].Kind)
+
+	issues = CheckCompatibility(oldSchema, newSchema, COMPATIBILITY_FULL)
+	assert.Len(t, issues, 6)
+
+	assert.Nil(t, CheckCompatibility(oldSchema, newSchema, COMPATIBILITY_NONE))
+	assert.True(t, IsCompatible(oldSchema, oldSchema, COMPATIBILITY_FULL))
+	assert.Equal(t, "code: type_changed (backward)", CheckCompatibility(oldSchema, newSchema, COMPATIBILITY_BACKWARD)[1].Error())
+}
+
+func TestCheckCompatibility_Details(t *testing.T) {
+
+	oldSchema := NewSchema()
+	err := UnmarshalJSON([]byte(`{
+	"status": { "type": "enum", "symbols": [ "ACTIVE", "INACTIVE", "DELETED" ] },
+	"price": { "type": "decimal", "precision": 10, "scale": 4 },
+	"value": { "type": "union", "types": [ "int", "string" ] },
+	"payload": {
+		"type": "union",
+		"discriminator": "kind",
+		"types": {
+			"click": {
+				"type": "map",
+				"fields": {
+					"x": { "type": "int" }
+				}
+			},
+			"view": {
+				"type": "map",
+				"fields": {
+					"page": { "type": "string" }
+				}
+			}
+		}
+	},
+	"label": { "type": "union", "types": [ "string", { "type": "map", "fields": { "text": { "type": "string" } } } ] }
+}`), oldSchema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	newSchema := NewSchema()
+	err = UnmarshalJSON([]byte(`{
+	"status": { "type": "enum", "symbols": [ "ACTIVE", "INACTIVE" ] },
+	"price": { "type": "decimal", "precision": 10, "scale": 2 },
+	"value": { "type": "union", "types": [ "int", "bool" ] },
+	"payload": {
+		"type": "union",
+		"discriminator": "kind",
+		"types": {
+			"click": {
+				"type": "map",
+				"fields": {
+					"x": { "type": "bool" }
+				}
+			}
+		}
+	},
+	"label": { "type": "string" }
+}`), newSchema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// New schema reads old records
+	issues := CheckCompatibility(oldSchema, newSchema, COMPATIBILITY_BACKWARD)
+	if !assert.Len(t, issues, 6) {
+		return
+	}
+
+	assert.Equal(t, "label", issues[0].Path)
+	assert.Equal(t, ISSUE_TYPE_CHANGED, issues[0].Kind)
+	assert.Equal(t, "payload", issues[1].Path)
+	assert.Equal(t, ISSUE_UNION_CHANGED, issues[1].Kind)
+	assert.Equal(t, "payload.x", issues[2].Path)
+	assert.Equal(t, ISSUE_TYPE_CHANGED, issues[2].Kind)
+	assert.Equal(t, "price", issues[3].Path)
+	assert.Equal(t, ISSUE_PRECISION, issues[3].Kind)
+	assert.Equal(t, "status", issues[4].Path)
+	assert.Equal(t, ISSUE_SYMBOL_REMOVED, issues[4].Kind)
+	assert.Equal(t, "value", issues[5].Path)
+	assert.Equal(t, ISSUE_UNION_CHANGED, issues[5].Kind)
+
+	// Old schema reads new records
+	issues = CheckCompatibility(oldSchema, newSchema, COMPATIBILITY_FORWARD)
+	if !assert.Len(t, issues, 3) {
+		return
+	}
+
+	// Union cannot be read as string, and boolean can be read as string
+	assert.Equal(t, "label", issues[0].Path)
+	assert.Equal(t, ISSUE_TYPE_CHANGED, issues[0].Kind)
+	assert.Equal(t, "payload.x", issues[1].Path)
+	assert.Equal(t, "price", issues[2].Path)
+	assert.Equal(t, ISSUE_PRECISION, issues[2].Kind)
+
+	// Adding symbols and widening decimal are compatible
+	widened := NewSchema()
+	err = UnmarshalJSON([]byte(`{
+	"status": { "type": "enum", "symbols": [ "ACTIVE", "INACTIVE", "DELETED", "ARCHIVED" ] },
+	"price": { "type": "decimal", "precision": 12, "scale": 4 },
+	"value": { "type": "union", "types": [ "int", "string", "bool" ] }
+}`), widened)
+	if err != nil {
+		t.Error(err)
+	}
+
+	oldSchema.Fields = map[string]*Definition{
+		"status": oldSchema.Fields["status"],
+		"price":  oldSchema.Fields["price"],
+		"value":  oldSchema.Fields["value"],
+	}
+
+	assert.True(t, IsCompatible(oldSchema, widened, COMPATIBILITY_BACKWARD))
+}
+
+func TestCheckCompatibility_UnlimitedDecimal(t *testing.T) {
+
+	limited := NewSchema()
+	err := UnmarshalJSON([]byte(`{
+	"price": { "type": "decimal", "precision": 10, "scale": 2 }
+}`), limited)
+	if err != nil {
+		t.Error(err)
+	}
+
+	unlimited := NewSchema()
+	err = UnmarshalJSON([]byte(`{
+	"price": { "type": "decimal" }
+}`), unlimited)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Reader without precision and scale accepts any decimal
+	assert.Nil(t, CheckCompatibility(limited, unlimited, COMPATIBILITY_BACKWARD))
+
+	// Limited reader cannot read unlimited decimal
+	issues := CheckCompatibility(limited, unlimited, COMPATIBILITY_FORWARD)
+	if assert.Len(t, issues, 1) {
+		assert.Equal(t, ISSUE_PRECISION, issues[0].Kind)
+	}
+
+	// Only scale is limited
+	scaled := NewSchema()
+	err = UnmarshalJSON([]byte(`{
+	"price": { "type": "decimal", "scale": 4 }
+}`), scaled)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Nil(t, CheckCompatibility(limited, scaled, COMPATIBILITY_BACKWARD))
+}
@@ -0,0 +1,77 @@
+package schemer
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ConversionReason string
+
+const (
+	CONVERSION_INVALID_TYPE     ConversionReason = "invalid_type"
+	CONVERSION_UNPARSABLE       ConversionReason = "unparsable"
+	CONVERSION_OVERFLOW         ConversionReason = "overflow"
+	CONVERSION_PRECISION_LOSS   ConversionReason = "precision_loss"
+	CONVERSION_NEGATIVE_TO_UINT ConversionReason = "negative_to_uint"
+	CONVERSION_NESTED_TO_STRING ConversionReason = "nested_to_string"
+	CONVERSION_CONSTRAINT       ConversionReason = "constraint"
+)
+
+var conversionReasons = map[error]ConversionReason{
+	ErrInvalidType:        CONVERSION_INVALID_TYPE,
+	ErrUnparsable:         CONVERSION_UNPARSABLE,
+	ErrOverflow:           CONVERSION_OVERFLOW,
+	ErrPrecisionLoss:      CONVERSION_PRECISION_LOSS,
+	ErrNegativeToUnsigned: CONVERSION_NEGATIVE_TO_UINT,
+	ErrNestedToString:     CONVERSION_NESTED_TO_STRING,
+}
+
+// ConversionError describes a value which cannot be converted to the type of definition
+type ConversionError struct {
+	Path       string
+	Source     interface{}
+	SourceType string
+	Target     ValueType
+	Reason     ConversionReason
+	Policy     ErrorPolicy
+	Err        error
+}
+
+// NewConversionError creates error for value at specific path. Path of error from nested value will be joined.
+func NewConversionError(path string, def *Definition, data interface{}, err error) *ConversionError {
+
+	if e, ok := err.(*ConversionError); ok {
+
+		nested := *e
+		if strings.HasPrefix(e.Path, "[") {
+			nested.Path = path + e.Path
+		} else {
+			nested.Path = joinPath(path, e.Path)
+		}
+
+		return &nested
+	}
+
+	reason, ok := conversionReasons[err]
+	if !ok {
+		// Values which violate constraints
+		reason = CONVERSION_CONSTRAINT
+	}
+
+	return &ConversionError{
+		Path:       path,
+		Source:     data,
+		SourceType: fmt.Sprintf("%T", data),
+		Target:     def.Type,
+		Reason:     reason,
+		Err:        err,
+	}
+}
+
+func (e *ConversionError) Error() string {
+	return fmt.Sprintf("%s: cannot convert %s to %s (%s)", e.Path, e.SourceType, e.Target, e.Reason)
+}
+
+func (e *ConversionError) Unwrap() error {
+	return e.Err
+}
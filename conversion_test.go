@@ -0,0 +1,213 @@
+package schemer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeWithErrors(t *testing.T) {
+
+	definition := `{
+	"id": { "type": "uint" },
+	"count": { "type": "int" },
+	"name": { "type": "string" },
+	"enabled": { "type": "bool" },
+	"level": { "type": "int8" },
+	"scores": { "type": "array", "subtype": "float" },
+	"meta": {
+		"type": "map",
+		"fields": {
+			"size": { "type": "uint" }
+		}
+	}
+}`
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(definition), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	result, errs := schema.NormalizeWithErrors(map[string]interface{}{
+		"id":      int64(-1),
+		"count":   "18446744073709551616",
+		"name":    map[string]interface{}{"first": "Fred"},
+		"enabled": "maybe",
+		"level":   nil,
+		"scores":  []interface{}{float64(1), "high"},
+		"meta": map[string]interface{}{
+			"size": float64(1.5),
+		},
+	})
+
+	assert.Equal(t, `{"first":"Fred"}`, result["name"])
+	assert.Nil(t, result["scores"])
+
+	if !assert.Len(t, errs, 6) {
+		return
+	}
+
+	assert.Equal(t, "count", errs[0].Path)
+	assert.Equal(t, CONVERSION_OVERFLOW, errs[0].Reason)
+	assert.Equal(t, "enabled", errs[1].Path)
+	assert.Equal(t, CONVERSION_UNPARSABLE, errs[1].Reason)
+	assert.Equal(t, "id", errs[2].Path)
+	assert.Equal(t, CONVERSION_NEGATIVE_TO_UINT, errs[2].Reason)
+	assert.Equal(t, "int64", errs[2].SourceType)
+	assert.Equal(t, int64(-1), errs[2].Source)
+	assert.Equal(t, TYPE_UINT64, errs[2].Target)
+	assert.Equal(t, "meta.size", errs[3].Path)
+	assert.Equal(t, CONVERSION_PRECISION_LOSS, errs[3].Reason)
+	assert.Equal(t, "name", errs[4].Path)
+	assert.Equal(t, CONVERSION_NESTED_TO_STRING, errs[4].Reason)
+	assert.Equal(t, "scores[1]", errs[5].Path)
+	assert.Equal(t, CONVERSION_UNPARSABLE, errs[5].Reason)
+	assert.Equal(t, TYPE_FLOAT64, errs[5].Target)
+
+	assert.True(t, errors.Is(errs[2], ErrNegativeToUnsigned))
+	assert.Equal(t, "id: cannot convert int64 to uint (negative_to_uint)", errs[2].Error())
+
+	// Valid data
+	_, errs = schema.NormalizeWithErrors(map[string]interface{}{
+		"id":    uint64(1),
+		"count": "100",
+	})
+
+	assert.Nil(t, errs)
+}
+
+func TestNormalizeWithErrors_InvalidMap(t *testing.T) {
+
+	definition := `{
+	"meta": {
+		"type": "map",
+		"fields": {
+			"size": { "type": "uint" }
+		}
+	},
+	"extra": {
+		"type": "map",
+		"onError": "keepRaw",
+		"fields": {
+			"size": { "type": "uint" }
+		}
+	}
+}`
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(definition), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	result, errs := schema.NormalizeWithErrors(map[string]interface{}{
+		"meta":  "Brobridge",
+		"extra": "Gravity",
+	})
+
+	if assert.Len(t, errs, 2) {
+		assert.Equal(t, "extra", errs[0].Path)
+		assert.Equal(t, CONVERSION_INVALID_TYPE, errs[0].Reason)
+		assert.Equal(t, "meta", errs[1].Path)
+		assert.Equal(t, CONVERSION_INVALID_TYPE, errs[1].Reason)
+		assert.True(t, errors.Is(errs[1], ErrInvalidType))
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"meta":  nil,
+		"extra": "Gravity",
+	}, result)
+
+	// Record is rejected by fail policy
+	schema.OnError = ERROR_POLICY_FAIL
+	result, errs = schema.NormalizeWithErrors(map[string]interface{}{
+		"meta": "Brobridge",
+	})
+
+	assert.Nil(t, result)
+	assert.Len(t, errs, 1)
+}
+
+func TestRecordGetValueWithError(t *testing.T) {
+
+	definition := `{
+	"count": { "type": "int", "maximum": 10 },
+	"tags": { "type": "array", "subtype": "string" }
+}`
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(definition), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	record := NewRecord(schema, map[string]interface{}{
+		"count": int64(20),
+		"tags":  []interface{}{"a", []interface{}{"b"}},
+	})
+
+	v, err := record.GetValueWithError("count")
+	assert.Nil(t, v)
+	if assert.IsType(t, &ConversionError{}, err) {
+		assert.Equal(t, CONVERSION_CONSTRAINT, err.(*ConversionError).Reason)
+	}
+
+	v, err = record.GetValueWithError("tags")
+	assert.Nil(t, v)
+	if assert.IsType(t, &ConversionError{}, err) {
+		assert.Equal(t, "tags[1]", err.(*ConversionError).Path)
+		assert.Equal(t, CONVERSION_NESTED_TO_STRING, err.(*ConversionError).Reason)
+	}
+
+	assert.Nil(t, record.GetValue("count"))
+
+	// Unknown field
+	v, err = record.GetValueWithError("unknown")
+	assert.Nil(t, v)
+	assert.Nil(t, err)
+}
+
+func TestNormalizeWithErrors_BinaryArray(t *testing.T) {
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(`{ "data": { "type": "binary" } }`), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	result, errs := schema.NormalizeWithErrors(map[string]interface{}{
+		"data": []interface{}{int64(1), int64(2), int64(3)},
+	})
+
+	assert.Nil(t, errs)
+	assert.Equal(t, []byte{1, 2, 3}, result["data"])
+
+	// One bad element makes the whole array nil
+	testCases := map[string][]interface{}{
+		"data[0]": {int64(256), "x"},
+		"data[1]": {int64(1), "x", int64(3)},
+		"data[2]": {int64(1), int64(2), int64(-1)},
+	}
+
+	for path, data := range testCases {
+
+		result, errs = schema.NormalizeWithErrors(map[string]interface{}{
+			"data": data,
+		})
+
+		assert.Nil(t, result["data"], path)
+		if assert.Len(t, errs, 1, path) {
+			assert.Equal(t, path, errs[0].Path)
+		}
+	}
+
+	violations := schema.Validate(map[string]interface{}{
+		"data": []interface{}{int64(1), "x", int64(3)},
+	})
+
+	if assert.Len(t, violations, 1) {
+		assert.Equal(t, VIOLATION_UNPARSABLE, violations[0].Reason)
+	}
+}
@@ -2,6 +2,7 @@ package schemer
 
 import (
 	"fmt"
+	"math"
 	"math/big"
 	"reflect"
 	"strconv"
@@ -11,11 +12,37 @@ import (
 )
 
 var (
-	ErrInvalidType = fmt.Errorf("Invalid type")
+	ErrInvalidType        = fmt.Errorf("Invalid type")
+	ErrUnparsable         = fmt.Errorf("Unparsable value")
+	ErrOverflow           = fmt.Errorf("Value overflows target type")
+	ErrPrecisionLoss      = fmt.Errorf("Value loses precision in target type")
+	ErrNegativeToUnsigned = fmt.Errorf("Negative value cannot be converted to unsigned integer")
+	ErrNestedToString     = fmt.Errorf("Nested value cannot be converted to string")
 )
 
+// getTypeError converts errors of types package to conversion errors
+func getTypeError(err error) error {
+
+	switch err {
+	case nil:
+		return nil
+	case types.ErrOutOfRange:
+		return ErrOverflow
+	case types.ErrNotInEnum:
+		return err
+	}
+
+	return ErrUnparsable
+}
+
 func getStandardValue(data interface{}) interface{} {
 
+	// Time of day and duration are based on integer but not a number
+	switch data.(type) {
+	case types.TimeOfDay, time.Duration:
+		return data
+	}
+
 	v := reflect.ValueOf(data)
 
 	switch v.Kind() {
@@ -32,6 +59,26 @@ func getStandardValue(data interface{}) interface{} {
 
 func getValue(def *Definition, data interface{}) (interface{}, error) {
 
+	v, err := convertValue(def, data)
+	if err != nil {
+		return v, err
+	}
+
+	if def.Constraint == nil || v == nil {
+		return v, nil
+	}
+
+	// Values which violate constraints are invalid
+	err = def.Constraint.Check(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func convertValue(def *Definition, data interface{}) (interface{}, error) {
+
 	if !def.NotNull && data == nil {
 		return nil, ErrInvalidType
 	}
@@ -46,17 +93,32 @@ func getValue(def *Definition, data interface{}) (interface{}, error) {
 		return getUnsignedIntegerValue(def, v)
 	case TYPE_FLOAT64:
 		return getFloatValue(def, v)
+	case TYPE_INT8, TYPE_INT16, TYPE_INT32:
+		return getSizedIntegerValue(def, v)
+	case TYPE_UINT8, TYPE_UINT16, TYPE_UINT32:
+		return getSizedUnsignedIntegerValue(def, v)
+	case TYPE_FLOAT32:
+		return getFloat32Value(def, v)
 	case TYPE_BOOLEAN:
 		return getBoolValue(def, v)
 	case TYPE_STRING:
 		return getStringValue(def, v)
 	case TYPE_TIME:
-		t, err := def.Info.(*types.Time).GetValue(v)
-		if err == types.ErrEmptyValue {
-			return nil, ErrInvalidType
-		}
-
-		return t, nil
+		return getTimeValue(def, v)
+	case TYPE_DECIMAL:
+		return getDecimalValue(def, v)
+	case TYPE_UUID:
+		return getUUIDValue(def, v)
+	case TYPE_DATE:
+		return getDateValue(def, v)
+	case TYPE_TIMEOFDAY:
+		return getTimeOfDayValue(def, v)
+	case TYPE_DURATION:
+		return getDurationValue(def, v)
+	case TYPE_ENUM:
+		return getEnumValue(def, v)
+	case TYPE_UNION:
+		return getUnionValue(def, v)
 	case TYPE_BINARY:
 		return getBinaryValue(def, v)
 	case TYPE_MAP:
@@ -100,9 +162,22 @@ func getIntegerValue(def *Definition, data interface{}) (int64, error) {
 	case int64:
 		return d, nil
 	case uint64:
+		if d > math.MaxInt64 {
+			return int64(d), ErrOverflow
+		}
+
 		return int64(d), nil
 	case string:
+		bi, ok := new(big.Int).SetString(d, 10)
+		if !ok {
+			return 0, ErrUnparsable
+		}
+
 		result := wrapParseInt64(d)
+		if !bi.IsInt64() {
+			return result, ErrOverflow
+		}
+
 		return result, nil
 	case bool:
 		if d {
@@ -113,27 +188,64 @@ func getIntegerValue(def *Definition, data interface{}) (int64, error) {
 	case float64:
 		str := float64ToString(d)
 		result := wrapParseInt64(str)
+		if d != math.Trunc(d) {
+			return result, ErrPrecisionLoss
+		}
+
+		if d < math.MinInt64 || d >= math.MaxInt64 {
+			return result, ErrOverflow
+		}
+
 		return result, nil
 	case time.Time:
 		return d.Unix(), nil
+	case types.DecimalValue:
+		i := d.Int()
+		result := wrapParseInt64(i.String())
+		if !i.IsInt64() {
+			return result, ErrOverflow
+		}
+
+		return result, nil
+	case types.Date:
+		return d.EpochDays(), nil
+	case types.TimeOfDay:
+		return int64(d.Duration() / time.Second), nil
+	case time.Duration:
+		return int64(d / time.Second), nil
+	case types.EnumValue:
+		return d.Code, nil
 	}
 
-	return 0, nil
+	return 0, ErrInvalidType
 }
 
 func getUnsignedIntegerValue(def *Definition, data interface{}) (uint64, error) {
 
 	switch d := data.(type) {
 	case int64:
-		if d > 0 {
+		if d >= 0 {
 			return uint64(d), nil
 		}
 
-		return 0, ErrInvalidType
+		return 0, ErrNegativeToUnsigned
 	case uint64:
 		return d, nil
 	case string:
+		bi, ok := new(big.Int).SetString(d, 10)
+		if !ok {
+			return 0, ErrUnparsable
+		}
+
 		result := wrapParseUint64(d)
+		if bi.Sign() < 0 {
+			return result, ErrNegativeToUnsigned
+		}
+
+		if !bi.IsUint64() {
+			return result, ErrOverflow
+		}
+
 		return result, nil
 	case bool:
 		if d {
@@ -144,12 +256,48 @@ func getUnsignedIntegerValue(def *Definition, data interface{}) (uint64, error)
 	case float64:
 		str := float64ToString(d)
 		result := wrapParseUint64(str)
+		if d < 0 {
+			return result, ErrNegativeToUnsigned
+		}
+
+		if d != math.Trunc(d) {
+			return result, ErrPrecisionLoss
+		}
+
+		if d >= math.MaxUint64 {
+			return result, ErrOverflow
+		}
+
 		return result, nil
 	case time.Time:
 		return uint64(d.Unix()), nil
+	case types.DecimalValue:
+		if d.Sign() < 0 {
+			return 0, ErrNegativeToUnsigned
+		}
+
+		i := d.Int()
+		result := wrapParseUint64(i.String())
+		if !i.IsUint64() {
+			return result, ErrOverflow
+		}
+
+		return result, nil
+	case time.Duration:
+		if d < 0 {
+			return 0, ErrNegativeToUnsigned
+		}
+
+		return uint64(d / time.Second), nil
+	case types.EnumValue:
+		if d.Code < 0 {
+			return 0, ErrNegativeToUnsigned
+		}
+
+		return uint64(d.Code), nil
 	}
 
-	return 0, nil
+	return 0, ErrInvalidType
 }
 
 func getFloatValue(def *Definition, data interface{}) (float64, error) {
@@ -162,7 +310,7 @@ func getFloatValue(def *Definition, data interface{}) (float64, error) {
 	case string:
 		result, err := strconv.ParseFloat(d, 64)
 		if err != nil {
-			return 0, ErrInvalidType
+			return 0, ErrUnparsable
 		}
 
 		return result, nil
@@ -176,9 +324,19 @@ func getFloatValue(def *Definition, data interface{}) (float64, error) {
 		return d, nil
 	case time.Time:
 		return float64(d.Unix()), nil
+	case types.DecimalValue:
+		return d.Float64(), nil
+	case types.Date:
+		return float64(d.EpochDays()), nil
+	case types.TimeOfDay:
+		return d.Duration().Seconds(), nil
+	case time.Duration:
+		return d.Seconds(), nil
+	case types.EnumValue:
+		return float64(d.Code), nil
 	}
 
-	return 0, nil
+	return 0, ErrInvalidType
 }
 
 func getBoolValue(def *Definition, data interface{}) (bool, error) {
@@ -199,7 +357,7 @@ func getBoolValue(def *Definition, data interface{}) (bool, error) {
 	case string:
 		result, err := strconv.ParseBool(d)
 		if err != nil {
-			return false, ErrInvalidType
+			return false, ErrUnparsable
 		}
 
 		return result, nil
@@ -213,9 +371,23 @@ func getBoolValue(def *Definition, data interface{}) (bool, error) {
 		}
 	case time.Time:
 		return true, nil
+	case types.DecimalValue:
+		if d.Sign() > 0 {
+			return true, nil
+		} else {
+			return false, nil
+		}
+	case time.Duration:
+		if d > 0 {
+			return true, nil
+		} else {
+			return false, nil
+		}
+	case types.EnumValue:
+		return d.Code > 0, nil
 	}
 
-	return false, nil
+	return false, ErrInvalidType
 }
 
 func getStringValue(def *Definition, data interface{}) (string, error) {
@@ -232,13 +404,35 @@ func getStringValue(def *Definition, data interface{}) (string, error) {
 	case float64:
 		return strconv.FormatFloat(d, 'f', -1, 64), nil
 	case time.Time:
+		if t, ok := def.Info.(*types.Time); ok {
+			return t.FormatValue(d), nil
+		}
+
 		return d.UTC().Format(time.RFC3339Nano), nil
+	case types.DecimalValue:
+		return d.String(), nil
+	case types.UUID:
+		return d.String(), nil
+	case types.Date:
+		return d.String(), nil
+	case types.TimeOfDay:
+		return d.String(), nil
+	case time.Duration:
+		return d.String(), nil
+	case types.EnumValue:
+		return d.Symbol, nil
+	case []byte:
+		if b, ok := def.Info.(*types.Binary); ok {
+			return b.Encode(d), nil
+		}
+
+		return fmt.Sprintf("%v", d), nil
 	case map[string]interface{}:
 		jsonData, _ := json.Marshal(d)
-		return string(jsonData), ErrInvalidType
+		return string(jsonData), ErrNestedToString
 	case []interface{}:
 		jsonData, _ := json.Marshal(d)
-		return string(jsonData), ErrInvalidType
+		return string(jsonData), ErrNestedToString
 	default:
 		return fmt.Sprintf("%v", d), nil
 	}
@@ -250,11 +444,34 @@ func getBinaryValue(def *Definition, data interface{}) ([]byte, error) {
 	case []byte:
 		return d, nil
 	case string:
+		if b, ok := def.Info.(*types.Binary); ok {
+			val, err := b.Decode(d)
+			if err != nil {
+				return []byte(""), ErrUnparsable
+			}
+
+			return val, nil
+		}
+
 		return []byte(d), nil
+	case types.DecimalValue:
+		return d.Bytes(), nil
+	case types.UUID:
+		return d.Bytes(), nil
 	case []interface{}:
+
+		// Whole array is rejected if any element is not a byte between 0 and 255
 		val := make([]byte, len(d))
 		for i, v := range d {
-			b, _ := getUnsignedIntegerValue(def, v)
+			b, err := getUnsignedIntegerValue(def, getStandardValue(v))
+			if err == nil && b > math.MaxUint8 {
+				err = ErrOverflow
+			}
+
+			if err != nil {
+				return nil, NewConversionError(fmt.Sprintf("[%d]", i), def, v, err)
+			}
+
 			val[i] = byte(b)
 		}
 
@@ -264,6 +481,118 @@ func getBinaryValue(def *Definition, data interface{}) ([]byte, error) {
 	return []byte(""), ErrInvalidType
 }
 
+func getTimeValue(def *Definition, data interface{}) (interface{}, error) {
+
+	switch data.(type) {
+	case int64, uint64, float64, time.Time, string, types.Date, types.TimeOfDay:
+	default:
+		return nil, ErrInvalidType
+	}
+
+	t, err := def.Info.(*types.Time).GetValue(data)
+	if err == types.ErrEmptyValue {
+		return nil, ErrInvalidType
+	}
+
+	return t, getTypeError(err)
+}
+
+func getDecimalValue(def *Definition, data interface{}) (interface{}, error) {
+
+	switch data.(type) {
+	case int64, uint64, float64, string, []byte, bool, types.DecimalValue:
+	default:
+		return nil, ErrInvalidType
+	}
+
+	d, err := def.Info.(*types.Decimal).GetValue(data)
+	if err != nil {
+		return nil, getTypeError(err)
+	}
+
+	return d, nil
+}
+
+func getUUIDValue(def *Definition, data interface{}) (interface{}, error) {
+
+	switch data.(type) {
+	case string, []byte, types.UUID:
+	default:
+		return nil, ErrInvalidType
+	}
+
+	u, err := types.ParseUUID(data)
+	if err != nil {
+		return nil, getTypeError(err)
+	}
+
+	return u, nil
+}
+
+func getDateValue(def *Definition, data interface{}) (interface{}, error) {
+
+	switch data.(type) {
+	case int64, uint64, float64, time.Time, string, types.Date:
+	default:
+		return nil, ErrInvalidType
+	}
+
+	d, err := def.Info.(*types.Time).GetDate(data)
+	if err != nil {
+		return nil, getTypeError(err)
+	}
+
+	return d, nil
+}
+
+func getTimeOfDayValue(def *Definition, data interface{}) (interface{}, error) {
+
+	switch data.(type) {
+	case int64, uint64, float64, time.Time, string, types.TimeOfDay:
+	default:
+		return nil, ErrInvalidType
+	}
+
+	tod, err := def.Info.(*types.Time).GetTimeOfDay(data)
+	if err != nil {
+		return nil, getTypeError(err)
+	}
+
+	return tod, nil
+}
+
+func getDurationValue(def *Definition, data interface{}) (interface{}, error) {
+
+	switch data.(type) {
+	case int64, uint64, float64, string, time.Duration:
+	default:
+		return nil, ErrInvalidType
+	}
+
+	d, err := def.Info.(*types.Duration).GetValue(data)
+	if err != nil {
+		return nil, getTypeError(err)
+	}
+
+	return d, nil
+}
+
+func getEnumValue(def *Definition, data interface{}) (interface{}, error) {
+
+	switch data.(type) {
+	case int64, uint64, float64, string, types.EnumValue:
+	default:
+		return nil, ErrInvalidType
+	}
+
+	e, err := def.Info.(*types.Enum).GetValue(data)
+	if err != nil {
+		return nil, getTypeError(err)
+	}
+
+	return e, nil
+}
+
 func getMapValue(def *Definition, data interface{}) (map[string]interface{}, error) {
 
 	switch d := data.(type) {
@@ -287,7 +616,7 @@ func getArrayValue(def *Definition, data interface{}) (interface{}, error) {
 		for i, v := range d {
 			val, err := getValue(def.Subtype, v)
 			if err != nil {
-				return nil, ErrInvalidType
+				return nil, NewConversionError(fmt.Sprintf("[%d]", i), def.Subtype, v, err)
 			}
 
 			value[i] = val
@@ -307,7 +636,7 @@ func getArrayValue(def *Definition, data interface{}) (interface{}, error) {
 		// Get value of element
 		val, err := getValue(def.Subtype, v.Index(i).Interface())
 		if err != nil {
-			return nil, ErrInvalidType
+			return nil, NewConversionError(fmt.Sprintf("[%d]", i), def.Subtype, v.Index(i).Interface(), err)
 		}
 
 		value[i] = val
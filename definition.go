@@ -2,6 +2,8 @@ package schemer
 
 import (
 	"errors"
+	"reflect"
+	"time"
 
 	"github.com/BrobridgeOrg/schemer/types"
 )
@@ -11,22 +13,56 @@ var (
 	ErrInvalidFieldsDefinition  = errors.New("Invalid fields definition")
 	ErrInvalidNotNullDefinition = errors.New("Invalid notNull definition")
 	ErrInvalidArraySubtype      = errors.New("Array type requires subtype")
+	ErrInvalidDefaultDefinition = errors.New("Invalid default definition")
+	ErrInvalidUnionDefinition   = errors.New("Union type requires types")
+	ErrInvalidRefDefinition     = errors.New("Invalid $ref definition")
+	ErrInvalidDefsDefinition    = errors.New("Invalid $defs definition")
 )
 
+// Properties which are parsed as constraints
+var constraintProps = []string{
+	"minimum",
+	"maximum",
+	"exclusiveMinimum",
+	"exclusiveMaximum",
+	"multipleOf",
+	"minLength",
+	"maxLength",
+	"minItems",
+	"maxItems",
+	"pattern",
+	"enum",
+}
+
 type RawDefinition struct {
 	Type    ValueType
 	Subtype *RawDefinition
 	Fields  map[string]*RawDefinition
+	Types   []*RawDefinition
+	Mapping map[string]*RawDefinition
 	NotNull bool
+	Ref     string
 	Props   map[string]interface{}
 }
 
 type Definition struct {
-	Schema  *Schema
-	Type    ValueType
-	Subtype *Definition
-	Info    interface{}
-	NotNull bool
+	Schema     *Schema
+	Type       ValueType
+	Subtype    *Definition
+	Info       interface{}
+	NotNull    bool
+	Constraint *types.Constraint
+	Default    interface{}
+	OnError    ErrorPolicy
+	Props      map[string]interface{}
+
+	// Alternatives of union. Mapping is used to select alternative by value of discriminator field.
+	Types         []*Definition
+	Discriminator string
+	Mapping       map[string]*Definition
+
+	// Name of shared definition in $defs
+	Ref string
 }
 
 func NewRawDefinition() *RawDefinition {
@@ -47,12 +83,14 @@ func NewDefinition(t ValueType) *Definition {
 
 func UnmarshalDefinition(data interface{}, d *Definition) error {
 
-	raw, err := extractRawDefinition(data)
+	def, err := unmarshalDefinition(data)
 	if err != nil {
 		return err
 	}
 
-	def, err := createDefinitionFromRawDefinition(raw)
+	// Named definitions are not available without schema
+	r := &refResolver{}
+	err = r.resolveRef(def, func(*Definition) {})
 	if err != nil {
 		return err
 	}
@@ -63,15 +101,51 @@ func UnmarshalDefinition(data interface{}, d *Definition) error {
 	d.Schema = def.Schema
 	d.Info = def.Info
 	d.NotNull = def.NotNull
+	d.Constraint = def.Constraint
+	d.Default = def.Default
+	d.OnError = def.OnError
+	d.Props = def.Props
+	d.Types = def.Types
+	d.Discriminator = def.Discriminator
+	d.Mapping = def.Mapping
+	d.Ref = def.Ref
 
 	return nil
 }
 
+func unmarshalDefinition(data interface{}) (*Definition, error) {
+
+	raw, err := extractRawDefinition(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return createDefinitionFromRawDefinition(raw)
+}
+
 func createDefinitionFromRawDefinition(raw *RawDefinition) (*Definition, error) {
 
 	def := NewDefinition(raw.Type)
 	def.NotNull = raw.NotNull
 
+	// Reference will be replaced with shared definition later
+	if len(raw.Ref) > 0 {
+		def.Ref = raw.Ref
+		return def, nil
+	}
+
+	// Keep properties for serialization. Subtype will be generated from definition.
+	if len(raw.Props) > 0 {
+		def.Props = make(map[string]interface{}, len(raw.Props))
+		for key, value := range raw.Props {
+			if key == "subtype" || key == "types" {
+				continue
+			}
+
+			def.Props[key] = value
+		}
+	}
+
 	switch def.Type {
 	case TYPE_MAP:
 		s, err := createSchemaFromRawFields(raw.Fields)
@@ -94,10 +168,112 @@ func createDefinitionFromRawDefinition(raw *RawDefinition) (*Definition, error)
 
 		def.Subtype = subDef
 
-	case TYPE_TIME:
+	case TYPE_UNION:
+		err := createUnionDefinition(def, raw)
+		if err != nil {
+			return nil, err
+		}
+
+	case TYPE_TIME, TYPE_DATE, TYPE_TIMEOFDAY:
 		t := types.NewTime()
-		t.Parse(raw.Props)
+		err := t.Parse(raw.Props)
+		if err != nil {
+			return nil, err
+		}
+
 		def.Info = t
+
+	case TYPE_STRING:
+
+		// Output format and time zone for time
+		_, hasFormat := raw.Props["format"]
+		_, hasTimezone := raw.Props["timezone"]
+		if hasFormat || hasTimezone {
+			t := types.NewTime()
+			err := t.Parse(raw.Props)
+			if err != nil {
+				return nil, err
+			}
+
+			def.Info = t
+		} else if _, ok := raw.Props["encoding"]; ok {
+
+			// Encoding for binary
+			b := types.NewBinary()
+			err := b.Parse(raw.Props)
+			if err != nil {
+				return nil, err
+			}
+
+			def.Info = b
+		}
+
+	case TYPE_BINARY:
+		b := types.NewBinary()
+		err := b.Parse(raw.Props)
+		if err != nil {
+			return nil, err
+		}
+
+		def.Info = b
+
+	case TYPE_ENUM:
+		e := types.NewEnum()
+		err := e.Parse(raw.Props)
+		if err != nil {
+			return nil, err
+		}
+
+		def.Info = e
+
+	case TYPE_DURATION:
+		d := types.NewDuration()
+		err := d.Parse(raw.Props)
+		if err != nil {
+			return nil, err
+		}
+
+		def.Info = d
+
+	case TYPE_DECIMAL:
+		d := types.NewDecimal()
+		err := d.Parse(raw.Props)
+		if err != nil {
+			return nil, err
+		}
+
+		def.Info = d
+	}
+
+	// Constraints
+	c := types.NewConstraint()
+	err := c.Parse(raw.Props)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.IsEmpty() {
+		def.Constraint = c
+	}
+
+	// Default value
+	if v, ok := raw.Props["default"]; ok && v != nil {
+		d, err := getDefaultValue(def, v)
+		if err != nil {
+			return nil, err
+		}
+
+		def.Default = d
+	}
+
+	// Policy for conversion failures
+	if v, ok := raw.Props["onError"]; ok {
+		p, err := parseErrorPolicy(v)
+		if err != nil {
+			return nil, err
+		}
+
+		def.OnError = p
 	}
 
 	return def, nil
@@ -163,6 +339,11 @@ func extractRawDefinition(data interface{}) (*RawDefinition, error) {
 	switch v := data.(type) {
 	case map[string]interface{}:
 
+		// Reference to named definition
+		if _, ok := v["$ref"]; ok {
+			return extractRefRawDefinition(v)
+		}
+
 		// Handle type
 		t, ok := v["type"]
 		if !ok {
@@ -200,6 +381,14 @@ func extractRawDefinition(data interface{}) (*RawDefinition, error) {
 			raw.Subtype = subDef
 		}
 
+		// Handle alternatives for union
+		if raw.Type == TYPE_UNION {
+			err := extractUnionRawDefinition(raw, v)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		// Handle fields
 		f, ok := v["fields"]
 		if raw.Type == TYPE_MAP && (!ok || f == nil) {
@@ -251,3 +440,294 @@ func extractRawDefinition(data interface{}) (*RawDefinition, error) {
 
 	return raw, nil
 }
+
+func getDefaultValue(def *Definition, data interface{}) (interface{}, error) {
+
+	if def.Type == TYPE_MAP {
+		m, ok := data.(map[string]interface{})
+		if !ok {
+			return nil, ErrInvalidDefaultDefinition
+		}
+
+		return def.Schema.Normalize(m), nil
+	}
+
+	v, err := getValue(def, data)
+	if err != nil {
+		return nil, ErrInvalidDefaultDefinition
+	}
+
+	return v, nil
+}
+
+func (d *Definition) UnmarshalJSON(source []byte) error {
+
+	var raw interface{}
+	err := json.Unmarshal(source, &raw)
+	if err != nil {
+		return err
+	}
+
+	return UnmarshalDefinition(raw, d)
+}
+
+func (d *Definition) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.marshalField())
+}
+
+// marshal generates definition document which can be parsed by UnmarshalDefinition
+func (d *Definition) marshal() interface{} {
+
+	if len(d.Ref) > 0 {
+		doc := map[string]interface{}{
+			"$ref": refPrefix + d.Ref,
+		}
+
+		if d.NotNull {
+			doc["notNull"] = true
+		}
+
+		return doc
+	}
+
+	// Properties which are not parsed by definition are kept as they are
+	doc := make(map[string]interface{}, len(d.Props)+3)
+	for key, value := range d.Props {
+		doc[key] = value
+	}
+
+	delete(doc, "default")
+	delete(doc, "onError")
+	delete(doc, "discriminator")
+
+	if d.NotNull {
+		doc["notNull"] = true
+	}
+
+	if d.Default != nil {
+		doc["default"] = d.marshalDefault()
+	}
+
+	if len(d.OnError) > 0 {
+		doc["onError"] = string(d.OnError)
+	}
+
+	d.marshalInfo(doc)
+	d.marshalConstraint(doc)
+
+	switch d.Type {
+	case TYPE_ARRAY:
+		if d.Subtype != nil {
+			doc["subtype"] = d.Subtype.marshal()
+		}
+	case TYPE_UNION:
+		if d.Mapping != nil {
+			mapping := make(map[string]interface{}, len(d.Mapping))
+			for key, def := range d.Mapping {
+				mapping[key] = def.marshal()
+			}
+
+			doc["types"] = mapping
+			doc["discriminator"] = d.Discriminator
+		} else {
+			alternatives := make([]interface{}, len(d.Types))
+			for i, def := range d.Types {
+				alternatives[i] = def.marshal()
+			}
+
+			doc["types"] = alternatives
+		}
+	case TYPE_MAP:
+		fields := make(map[string]interface{})
+		if d.Schema != nil {
+			for name, def := range d.Schema.Fields {
+				fields[name] = def.marshalField()
+			}
+		}
+
+		doc["fields"] = fields
+	}
+
+	// Simple definition can be represented by type name only
+	if len(doc) == 0 && d.Type != TYPE_TIME {
+		return d.Type.String()
+	}
+
+	doc["type"] = d.Type.String()
+
+	return doc
+}
+
+// marshalDefault returns default value in the form which can be parsed again
+func (d *Definition) marshalDefault() interface{} {
+
+	switch v := d.Default.(type) {
+	case time.Duration:
+		return v.String()
+	case []byte:
+		if b, ok := d.Info.(*types.Binary); ok {
+			return b.Encode(v)
+		}
+	}
+
+	return d.Default
+}
+
+// marshalInfo writes properties of type information into document
+func (d *Definition) marshalInfo(doc map[string]interface{}) {
+
+	switch info := d.Info.(type) {
+	case *types.Time:
+		delete(doc, "precision")
+		delete(doc, "timezone")
+		delete(doc, "format")
+
+		if info.Precision != types.TIME_PRECISION_SECOND {
+			doc["precision"] = info.Precision.String()
+		}
+
+		if info.Location != nil {
+			doc["timezone"] = info.Location.String()
+		}
+
+		if len(info.Layouts) > 0 {
+			doc["format"] = marshalLayouts(d.Props["format"], info.Layouts)
+		}
+
+	case *types.Decimal:
+		delete(doc, "precision")
+		delete(doc, "scale")
+
+		// Zero precision and negative scale are unlimited
+		if info.Precision > 0 {
+			doc["precision"] = info.Precision
+		}
+
+		if info.Scale >= 0 {
+			doc["scale"] = info.Scale
+		}
+
+	case *types.Binary:
+		delete(doc, "encoding")
+
+		if len(info.Encoding) > 0 && info.Encoding != types.BINARY_ENCODING_RAW {
+			doc["encoding"] = info.Encoding
+		}
+
+	case *types.Enum:
+		delete(doc, "symbols")
+		delete(doc, "codes")
+
+		symbols := make([]interface{}, len(info.Symbols))
+		for i, symbol := range info.Symbols {
+			symbols[i] = symbol
+		}
+
+		doc["symbols"] = symbols
+
+		// Codes are omitted if they are indexes of symbols
+		for i, code := range info.Codes {
+			if code != int64(i) {
+				doc["codes"] = info.Codes
+				break
+			}
+		}
+
+	case *types.Duration:
+		delete(doc, "unit")
+
+		if info.Unit != time.Second {
+			for name, unit := range types.DurationUnits {
+				if unit == info.Unit {
+					doc["unit"] = name
+				}
+			}
+		}
+	}
+}
+
+// marshalLayouts returns time format. Original format is kept if it generates the same layouts.
+func marshalLayouts(format interface{}, layouts []string) interface{} {
+
+	if format != nil {
+		t := types.NewTime()
+		err := t.Parse(map[string]interface{}{
+			"format": format,
+		})
+		if err == nil && reflect.DeepEqual(t.Layouts, layouts) {
+			return format
+		}
+	}
+
+	if len(layouts) == 1 {
+		return layouts[0]
+	}
+
+	formats := make([]interface{}, len(layouts))
+	for i, layout := range layouts {
+		formats[i] = layout
+	}
+
+	return formats
+}
+
+// marshalConstraint writes constraints into document
+func (d *Definition) marshalConstraint(doc map[string]interface{}) {
+
+	for _, key := range constraintProps {
+		delete(doc, key)
+	}
+
+	c := d.Constraint
+	if c == nil {
+		return
+	}
+
+	numbers := map[string]*float64{
+		"minimum":          c.Minimum,
+		"maximum":          c.Maximum,
+		"exclusiveMinimum": c.ExclusiveMinimum,
+		"exclusiveMaximum": c.ExclusiveMaximum,
+		"multipleOf":       c.MultipleOf,
+	}
+
+	for key, n := range numbers {
+		if n != nil {
+			doc[key] = *n
+		}
+	}
+
+	lengths := map[string]*int{
+		"minLength": c.MinLength,
+		"maxLength": c.MaxLength,
+		"minItems":  c.MinItems,
+		"maxItems":  c.MaxItems,
+	}
+
+	for key, n := range lengths {
+		if n != nil {
+			doc[key] = *n
+		}
+	}
+
+	if c.Pattern != nil {
+		doc["pattern"] = c.Pattern.String()
+	}
+
+	if len(c.Enum) > 0 {
+		doc["enum"] = c.Enum
+	}
+}
+
+// marshalField generates definition document for a field, which always requires an object
+func (d *Definition) marshalField() interface{} {
+
+	doc := d.marshal()
+	if name, ok := doc.(string); ok {
+		return map[string]interface{}{
+			"type": name,
+		}
+	}
+
+	return doc
+}
@@ -0,0 +1,349 @@
+package schemer
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/BrobridgeOrg/schemer/types"
+)
+
+type DiffAction string
+
+const (
+	DIFF_ADDED   DiffAction = "added"
+	DIFF_REMOVED DiffAction = "removed"
+	DIFF_CHANGED DiffAction = "changed"
+)
+
+// PropertyChange describes a changed property of definition, such as type, notNull, precision or props
+type PropertyChange struct {
+	Property string
+	Old      interface{}
+	New      interface{}
+}
+
+// FieldDiff is a node of diff tree. Fields contains diffs of nested map and Subtype contains diff of array subtype.
+type FieldDiff struct {
+	Name    string
+	Path    string
+	Action  DiffAction
+	Old     *Definition
+	New     *Definition
+	Changes []*PropertyChange
+	Fields  []*FieldDiff
+	Subtype *FieldDiff
+}
+
+// SchemaDiff returns differences from schema a to schema b. It returns nil if schemas are equivalent.
+func SchemaDiff(a *Schema, b *Schema) []*FieldDiff {
+
+	diffs := diffSchema("", a, b)
+
+	if a != nil && b != nil {
+
+		// Settings of schema
+		diffs = append(diffs, diffSetting("$timezone", getTimezoneName(a), getTimezoneName(b))...)
+		diffs = append(diffs, diffSetting("$onError", string(a.OnError), string(b.OnError))...)
+
+		// Shared definitions are compared once, instead of the fields which refer to them
+		diffs = append(diffs, diffFields("$defs", a.Defs, b.Defs, diffDefinitionBody)...)
+	}
+
+	return diffs
+}
+
+func getTimezoneName(s *Schema) string {
+
+	if s.Timezone == nil {
+		return ""
+	}
+
+	return s.Timezone.String()
+}
+
+// diffSetting compares setting of schema, empty value means setting is not specified
+func diffSetting(name string, a string, b string) []*FieldDiff {
+
+	if a == b {
+		return nil
+	}
+
+	action := DIFF_CHANGED
+	switch {
+	case len(a) == 0:
+		action = DIFF_ADDED
+	case len(b) == 0:
+		action = DIFF_REMOVED
+	}
+
+	return []*FieldDiff{
+		{
+			Name:   name,
+			Path:   name,
+			Action: action,
+			Changes: []*PropertyChange{
+				{Property: name, Old: a, New: b},
+			},
+		},
+	}
+}
+
+func diffSchema(path string, a *Schema, b *Schema) []*FieldDiff {
+
+	if a == nil {
+		a = NewSchema()
+	}
+
+	if b == nil {
+		b = NewSchema()
+	}
+
+	return diffFields(path, a.Fields, b.Fields, diffDefinition)
+}
+
+func diffFields(path string, a map[string]*Definition, b map[string]*Definition, diffFn func(string, string, *Definition, *Definition) *FieldDiff) []*FieldDiff {
+
+	names := make(map[string]bool, len(a)+len(b))
+	for name := range a {
+		names[name] = true
+	}
+
+	for name := range b {
+		names[name] = true
+	}
+
+	sortedNames := make([]string, 0, len(names))
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+
+	sort.Strings(sortedNames)
+
+	var diffs []*FieldDiff
+	for _, name := range sortedNames {
+
+		oldDef, inA := a[name]
+		newDef, inB := b[name]
+		fieldPath := joinPath(path, name)
+
+		switch {
+		case !inA:
+			diffs = append(diffs, &FieldDiff{
+				Name:   name,
+				Path:   fieldPath,
+				Action: DIFF_ADDED,
+				New:    newDef,
+			})
+		case !inB:
+			diffs = append(diffs, &FieldDiff{
+				Name:   name,
+				Path:   fieldPath,
+				Action: DIFF_REMOVED,
+				Old:    oldDef,
+			})
+		default:
+			if d := diffFn(name, fieldPath, oldDef, newDef); d != nil {
+				diffs = append(diffs, d)
+			}
+		}
+	}
+
+	return diffs
+}
+
+func getTimePrecision(def *Definition) interface{} {
+
+	if t, ok := def.Info.(*types.Time); ok {
+		return t.Precision.String()
+	}
+
+	return nil
+}
+
+func diffDefinition(name string, path string, a *Definition, b *Definition) *FieldDiff {
+
+	if len(a.Ref) == 0 && len(b.Ref) == 0 {
+		return diffDefinitionBody(name, path, a, b)
+	}
+
+	diff := &FieldDiff{
+		Name:   name,
+		Path:   path,
+		Action: DIFF_CHANGED,
+		Old:    a,
+		New:    b,
+	}
+
+	if a.Ref != b.Ref {
+		diff.Changes = append(diff.Changes, &PropertyChange{
+			Property: "$ref",
+			Old:      a.Ref,
+			New:      b.Ref,
+		})
+	}
+
+	if a.NotNull != b.NotNull {
+		diff.Changes = append(diff.Changes, &PropertyChange{
+			Property: "notNull",
+			Old:      a.NotNull,
+			New:      b.NotNull,
+		})
+	}
+
+	if len(diff.Changes) == 0 {
+		return nil
+	}
+
+	return diff
+}
+
+// diffDefinitionBody compares definitions without following reference
+func diffDefinitionBody(name string, path string, a *Definition, b *Definition) *FieldDiff {
+
+	diff := &FieldDiff{
+		Name:   name,
+		Path:   path,
+		Action: DIFF_CHANGED,
+		Old:    a,
+		New:    b,
+	}
+
+	if a.Type != b.Type {
+		diff.Changes = append(diff.Changes, &PropertyChange{
+			Property: "type",
+			Old:      a.Type.String(),
+			New:      b.Type.String(),
+		})
+
+		return diff
+	}
+
+	if a.NotNull != b.NotNull {
+		diff.Changes = append(diff.Changes, &PropertyChange{
+			Property: "notNull",
+			Old:      a.NotNull,
+			New:      b.NotNull,
+		})
+	}
+
+	// Props which have been compared with Info
+	var compared []string
+
+	if a.Type == TYPE_TIME || a.Type == TYPE_TIMEOFDAY {
+		compared = []string{"precision"}
+		oldPrecision := getTimePrecision(a)
+		newPrecision := getTimePrecision(b)
+		if oldPrecision != newPrecision {
+			diff.Changes = append(diff.Changes, &PropertyChange{
+				Property: "precision",
+				Old:      oldPrecision,
+				New:      newPrecision,
+			})
+		}
+	}
+
+	if a.Type == TYPE_DECIMAL {
+		compared = []string{"precision", "scale"}
+		diff.Changes = append(diff.Changes, diffDecimal(a, b)...)
+	}
+
+	if a.Type == TYPE_UNION {
+		oldTypes := a.marshal().(map[string]interface{})["types"]
+		newTypes := b.marshal().(map[string]interface{})["types"]
+		if !reflect.DeepEqual(oldTypes, newTypes) {
+			diff.Changes = append(diff.Changes, &PropertyChange{
+				Property: "types",
+				Old:      oldTypes,
+				New:      newTypes,
+			})
+		}
+	}
+
+	diff.Changes = append(diff.Changes, diffProps(a.Props, b.Props, compared...)...)
+
+	switch a.Type {
+	case TYPE_MAP:
+		diff.Fields = diffSchema(path, a.Schema, b.Schema)
+	case TYPE_ARRAY:
+		if a.Subtype != nil && b.Subtype != nil {
+			diff.Subtype = diffDefinition("[]", path+"[]", a.Subtype, b.Subtype)
+		}
+	}
+
+	if len(diff.Changes) == 0 && len(diff.Fields) == 0 && diff.Subtype == nil {
+		return nil
+	}
+
+	return diff
+}
+
+func diffDecimal(a *Definition, b *Definition) []*PropertyChange {
+
+	oldDecimal, _ := a.Info.(*types.Decimal)
+	newDecimal, _ := b.Info.(*types.Decimal)
+	if oldDecimal == nil || newDecimal == nil {
+		return nil
+	}
+
+	var changes []*PropertyChange
+
+	if oldDecimal.Precision != newDecimal.Precision {
+		changes = append(changes, &PropertyChange{
+			Property: "precision",
+			Old:      oldDecimal.Precision,
+			New:      newDecimal.Precision,
+		})
+	}
+
+	if oldDecimal.Scale != newDecimal.Scale {
+		changes = append(changes, &PropertyChange{
+			Property: "scale",
+			Old:      oldDecimal.Scale,
+			New:      newDecimal.Scale,
+		})
+	}
+
+	return changes
+}
+
+func diffProps(a map[string]interface{}, b map[string]interface{}, skipped ...string) []*PropertyChange {
+
+	keys := make(map[string]bool, len(a)+len(b))
+	for key := range a {
+		keys[key] = true
+	}
+
+	for key := range b {
+		keys[key] = true
+	}
+
+	for _, key := range skipped {
+		delete(keys, key)
+	}
+
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+
+	sort.Strings(sortedKeys)
+
+	var changes []*PropertyChange
+	for _, key := range sortedKeys {
+
+		oldValue := a[key]
+		newValue := b[key]
+
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+
+		changes = append(changes, &PropertyChange{
+			Property: key,
+			Old:      oldValue,
+			New:      newValue,
+		})
+	}
+
+	return changes
+}
@@ -0,0 +1,199 @@
+package schemer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaDiff(t *testing.T) {
+
+	a := NewSchema()
+	err := UnmarshalJSON([]byte(`{
+	"id": { "type": "int", "notNull": true },
+	"name": { "type": "string", "maxLength": 32 },
+	"code": { "type": "string" },
+	"createdAt": { "type": "time" },
+	"tags": { "type": "array", "subtype": "string" },
+	"attributes": {
+		"type": "map",
+		"fields": {
+			"title": { "type": "string" },
+			"team": { "type": "string" }
+		}
+	}
+}`), a)
+	if err != nil {
+		t.Error(err)
+	}
+
+	b := NewSchema()
+	err = UnmarshalJSON([]byte(`{
+	"id": { "type": "int", "notNull": true },
+	"name": { "type": "string", "maxLength": 64, "notNull": true },
+	"score": { "type": "float" },
+	"createdAt": { "type": "time", "precision": "microsecond" },
+	"tags": { "type": "array", "subtype": "int" },
+	"attributes": {
+		"type": "map",
+		"fields": {
+			"title": { "type": "string" },
+			"level": { "type": "int" }
+		}
+	}
+}`), b)
+	if err != nil {
+		t.Error(err)
+	}
+
+	diffs := SchemaDiff(a, b)
+	if !assert.Len(t, diffs, 6) {
+		return
+	}
+
+	// attributes
+	assert.Equal(t, "attributes", diffs[0].Path)
+	assert.Equal(t, DIFF_CHANGED, diffs[0].Action)
+	assert.Empty(t, diffs[0].Changes)
+	if assert.Len(t, diffs[0].Fields, 2) {
+		assert.Equal(t, "attributes.level", diffs[0].Fields[0].Path)
+		assert.Equal(t, DIFF_ADDED, diffs[0].Fields[0].Action)
+		assert.Equal(t, "attributes.team", diffs[0].Fields[1].Path)
+		assert.Equal(t, DIFF_REMOVED, diffs[0].Fields[1].Action)
+	}
+
+	// code
+	assert.Equal(t, "code", diffs[1].Name)
+	assert.Equal(t, DIFF_REMOVED, diffs[1].Action)
+	assert.Equal(t, TYPE_STRING, diffs[1].Old.Type)
+
+	// createdAt
+	assert.Equal(t, "createdAt", diffs[2].Name)
+	assert.Equal(t, []*PropertyChange{
+		{Property: "precision", Old: "second", New: "microsecond"},
+	}, diffs[2].Changes)
+
+	// name
+	assert.Equal(t, "name", diffs[3].Name)
+	assert.Equal(t, []*PropertyChange{
+		{Property: "notNull", Old: false, New: true},
+		{Property: "maxLength", Old: float64(32), New: float64(64)},
+	}, diffs[3].Changes)
+
+	// score
+	assert.Equal(t, "score", diffs[4].Name)
+	assert.Equal(t, DIFF_ADDED, diffs[4].Action)
+
+	// tags
+	assert.Equal(t, "tags", diffs[5].Name)
+	assert.Empty(t, diffs[5].Changes)
+	if assert.NotNil(t, diffs[5].Subtype) {
+		assert.Equal(t, "tags[]", diffs[5].Subtype.Path)
+		assert.Equal(t, []*PropertyChange{
+			{Property: "type", Old: "string", New: "int"},
+		}, diffs[5].Subtype.Changes)
+	}
+
+	assert.Nil(t, SchemaDiff(a, a))
+}
+
+func TestSchemaDiff_Decimal(t *testing.T) {
+
+	a := NewSchema()
+	err := UnmarshalJSON([]byte(`{
+	"price": { "type": "decimal", "precision": 10, "scale": 2 },
+	"rate": { "type": "decimal", "precision": 10, "scale": 2 },
+	"amount": { "type": "decimal" }
+}`), a)
+	if err != nil {
+		t.Error(err)
+	}
+
+	b := NewSchema()
+	err = UnmarshalJSON([]byte(`{
+	"price": { "type": "decimal", "precision": 12, "scale": 2 },
+	"rate": { "type": "decimal", "precision": 10, "scale": 4 },
+	"amount": { "type": "decimal" }
+}`), b)
+	if err != nil {
+		t.Error(err)
+	}
+
+	diffs := SchemaDiff(a, b)
+	if !assert.Len(t, diffs, 2) {
+		return
+	}
+
+	assert.Equal(t, "price", diffs[0].Path)
+	assert.Equal(t, []*PropertyChange{
+		{Property: "precision", Old: int32(10), New: int32(12)},
+	}, diffs[0].Changes)
+
+	assert.Equal(t, "rate", diffs[1].Path)
+	assert.Equal(t, []*PropertyChange{
+		{Property: "scale", Old: int32(2), New: int32(4)},
+	}, diffs[1].Changes)
+}
+
+func TestSchemaDiff_Settings(t *testing.T) {
+
+	a := NewSchema()
+	err := UnmarshalJSON([]byte(`{
+	"$timezone": "Asia/Taipei",
+	"$defs": {
+		"address": {
+			"type": "map",
+			"fields": {
+				"city": { "type": "string" }
+			}
+		}
+	},
+	"home": { "$ref": "#/$defs/address" }
+}`), a)
+	if err != nil {
+		t.Error(err)
+	}
+
+	b := NewSchema()
+	err = UnmarshalJSON([]byte(`{
+	"$onError": "null",
+	"$defs": {
+		"address": {
+			"type": "map",
+			"fields": {
+				"city": { "type": "string" },
+				"zip": { "type": "string" }
+			}
+		}
+	},
+	"home": { "$ref": "#/$defs/address" }
+}`), b)
+	if err != nil {
+		t.Error(err)
+	}
+
+	diffs := SchemaDiff(a, b)
+	if !assert.Len(t, diffs, 3) {
+		return
+	}
+
+	assert.Equal(t, "$timezone", diffs[0].Path)
+	assert.Equal(t, DIFF_REMOVED, diffs[0].Action)
+	assert.Equal(t, []*PropertyChange{
+		{Property: "$timezone", Old: "Asia/Taipei", New: ""},
+	}, diffs[0].Changes)
+
+	assert.Equal(t, "$onError", diffs[1].Path)
+	assert.Equal(t, DIFF_ADDED, diffs[1].Action)
+	assert.Equal(t, []*PropertyChange{
+		{Property: "$onError", Old: "", New: "null"},
+	}, diffs[1].Changes)
+
+	assert.Equal(t, "$defs.address", diffs[2].Path)
+	if assert.Len(t, diffs[2].Fields, 1) {
+		assert.Equal(t, "$defs.address.zip", diffs[2].Fields[0].Path)
+		assert.Equal(t, DIFF_ADDED, diffs[2].Fields[0].Action)
+	}
+
+	assert.Nil(t, SchemaDiff(a, a))
+}
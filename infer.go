@@ -0,0 +1,259 @@
+package schemer
+
+import (
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/BrobridgeOrg/schemer/types"
+)
+
+// InferSchema proposes schema from sample records. Fields are marked as not null if they are never absent or null.
+func InferSchema(records []map[string]interface{}) *Schema {
+
+	var s *Schema
+	for _, record := range records {
+
+		rs := inferSchema(record)
+		if s == nil {
+			s = rs
+			continue
+		}
+
+		s = mergeSchema(s, rs)
+	}
+
+	if s == nil {
+		return NewSchema()
+	}
+
+	finalizeSchema(s)
+
+	return s
+}
+
+// MergeSchema returns a new schema which accepts records of both schemas by widening types
+func MergeSchema(a *Schema, b *Schema) *Schema {
+
+	s := mergeSchema(a, b)
+	finalizeSchema(s)
+
+	return s
+}
+
+func inferSchema(record map[string]interface{}) *Schema {
+
+	s := NewSchema()
+	for key, value := range record {
+
+		// Skip internal fields
+		if len(key) == 0 || key[0] == '$' {
+			continue
+		}
+
+		s.Fields[key] = inferDefinition(value)
+	}
+
+	return s
+}
+
+func newTimeDefinition() *Definition {
+	def := NewDefinition(TYPE_TIME)
+	def.Info = types.NewTime()
+	return def
+}
+
+func inferDefinition(data interface{}) *Definition {
+
+	var def *Definition
+
+	switch d := data.(type) {
+	case nil:
+		// Type is unknown until we get a value
+		return NewDefinition(TYPE_NULL)
+	case bool:
+		def = NewDefinition(TYPE_BOOLEAN)
+	case float64:
+		if d == math.Trunc(d) && !math.IsInf(d, 0) && math.Abs(d) < 1<<63 {
+			def = NewDefinition(TYPE_INT64)
+		} else {
+			def = NewDefinition(TYPE_FLOAT64)
+		}
+	case string:
+		if _, err := time.Parse(time.RFC3339Nano, d); err == nil {
+			def = newTimeDefinition()
+		} else if types.IsCanonicalUUID(d) {
+			def = NewDefinition(TYPE_UUID)
+		} else {
+			def = NewDefinition(TYPE_STRING)
+		}
+	case []byte:
+		def = NewDefinition(TYPE_BINARY)
+	case time.Time:
+		def = newTimeDefinition()
+	case types.UUID:
+		def = NewDefinition(TYPE_UUID)
+	case map[string]interface{}:
+		def = NewDefinition(TYPE_MAP)
+		def.Schema = inferSchema(d)
+	case []interface{}:
+		def = NewDefinition(TYPE_ARRAY)
+		def.Subtype = NewDefinition(TYPE_NULL)
+		for _, ele := range d {
+			def.Subtype = mergeDefinition(def.Subtype, inferDefinition(ele))
+		}
+	default:
+		switch reflect.ValueOf(data).Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			def = NewDefinition(TYPE_INT64)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			def = NewDefinition(TYPE_UINT64)
+		case reflect.Float32:
+			def = NewDefinition(TYPE_FLOAT64)
+		default:
+			def = NewDefinition(TYPE_ANY)
+		}
+	}
+
+	def.NotNull = true
+
+	return def
+}
+
+func mergeSchema(a *Schema, b *Schema) *Schema {
+
+	s := NewSchema()
+
+	for key, def := range a.Fields {
+
+		other, ok := b.Fields[key]
+		if !ok {
+			// Field is absent in some records
+			d := copyDefinition(def)
+			d.NotNull = false
+			s.Fields[key] = d
+			continue
+		}
+
+		s.Fields[key] = mergeDefinition(def, other)
+	}
+
+	for key, def := range b.Fields {
+
+		if _, ok := a.Fields[key]; ok {
+			continue
+		}
+
+		d := copyDefinition(def)
+		d.NotNull = false
+		s.Fields[key] = d
+	}
+
+	return s
+}
+
+func copyDefinition(def *Definition) *Definition {
+
+	d := *def
+
+	if def.Schema != nil {
+		d.Schema = NewSchema()
+		for key, field := range def.Schema.Fields {
+			d.Schema.Fields[key] = copyDefinition(field)
+		}
+	}
+
+	if def.Subtype != nil {
+		d.Subtype = copyDefinition(def.Subtype)
+	}
+
+	return &d
+}
+
+func isNumberType(t ValueType) bool {
+	if t == TYPE_FLOAT64 || t == TYPE_FLOAT32 {
+		return true
+	}
+
+	return isSignedIntegerType(t) || isUnsignedIntegerType(t)
+}
+
+func isScalarType(t ValueType) bool {
+	switch t {
+	case TYPE_MAP, TYPE_ARRAY, TYPE_ANY, TYPE_NULL:
+		return false
+	}
+
+	return true
+}
+
+// mergeDefinition returns a definition which is wide enough for both definitions
+func mergeDefinition(a *Definition, b *Definition) *Definition {
+
+	notNull := a.NotNull && b.NotNull
+
+	if a.Type == TYPE_NULL {
+		d := copyDefinition(b)
+		d.NotNull = false
+		return d
+	}
+
+	if b.Type == TYPE_NULL {
+		d := copyDefinition(a)
+		d.NotNull = false
+		return d
+	}
+
+	var def *Definition
+
+	switch {
+	case a.Type == b.Type:
+		def = copyDefinition(a)
+
+		switch a.Type {
+		case TYPE_MAP:
+			def.Schema = mergeSchema(a.Schema, b.Schema)
+		case TYPE_ARRAY:
+			def.Subtype = mergeDefinition(a.Subtype, b.Subtype)
+		}
+
+	case isNumberType(a.Type) && isNumberType(b.Type):
+		if a.Type == TYPE_FLOAT64 || b.Type == TYPE_FLOAT64 || a.Type == TYPE_FLOAT32 || b.Type == TYPE_FLOAT32 {
+			def = NewDefinition(TYPE_FLOAT64)
+		} else {
+			def = NewDefinition(TYPE_INT64)
+		}
+
+	case isScalarType(a.Type) && isScalarType(b.Type):
+		// All scalar values can be represented by string
+		def = NewDefinition(TYPE_STRING)
+
+	default:
+		def = NewDefinition(TYPE_ANY)
+	}
+
+	def.NotNull = notNull
+
+	return def
+}
+
+// finalizeSchema replaces unknown types which only have null values
+func finalizeSchema(s *Schema) {
+
+	for _, def := range s.Fields {
+		finalizeDefinition(def)
+	}
+}
+
+func finalizeDefinition(def *Definition) {
+
+	switch def.Type {
+	case TYPE_NULL:
+		def.Type = TYPE_ANY
+		def.NotNull = false
+	case TYPE_MAP:
+		finalizeSchema(def.Schema)
+	case TYPE_ARRAY:
+		finalizeDefinition(def.Subtype)
+	}
+}
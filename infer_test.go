@@ -0,0 +1,127 @@
+package schemer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInferSchema(t *testing.T) {
+
+	samples := `[
+	{
+		"id": 1,
+		"name": "Fred",
+		"score": 10,
+		"code": 100,
+		"createdAt": "2020-07-19T18:16:08Z",
+		"deletedAt": null,
+		"tags": [ "a", "b" ],
+		"values": [ 1, 2.5 ],
+		"empty": [],
+		"attributes": {
+			"title": "Architect"
+		},
+		"attachments": [
+			{ "filename": "file1.txt" }
+		]
+	},
+	{
+		"id": 2,
+		"name": "Bob",
+		"nickname": "Bobby",
+		"score": 9.5,
+		"code": "A100",
+		"createdAt": "2020-07-20T18:16:08.123Z",
+		"deletedAt": null,
+		"tags": [],
+		"values": null,
+		"empty": [],
+		"attributes": {
+			"title": "Engineer",
+			"team": "product"
+		},
+		"attachments": [
+			{ "filename": "file2.txt", "size": 123 }
+		]
+	}
+]`
+
+	var records []map[string]interface{}
+	err := json.Unmarshal([]byte(samples), &records)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	schema := InferSchema(records)
+
+	assert.Equal(t, TYPE_INT64, schema.Fields["id"].Type)
+	assert.True(t, schema.Fields["id"].NotNull)
+	assert.Equal(t, TYPE_STRING, schema.Fields["name"].Type)
+	assert.True(t, schema.Fields["name"].NotNull)
+	assert.Equal(t, TYPE_STRING, schema.Fields["nickname"].Type)
+	assert.False(t, schema.Fields["nickname"].NotNull)
+	assert.Equal(t, TYPE_FLOAT64, schema.Fields["score"].Type)
+	assert.Equal(t, TYPE_STRING, schema.Fields["code"].Type)
+	assert.Equal(t, TYPE_TIME, schema.Fields["createdAt"].Type)
+	assert.NotNil(t, schema.Fields["createdAt"].Info)
+	assert.Equal(t, TYPE_ANY, schema.Fields["deletedAt"].Type)
+	assert.False(t, schema.Fields["deletedAt"].NotNull)
+
+	assert.Equal(t, TYPE_ARRAY, schema.Fields["tags"].Type)
+	assert.Equal(t, TYPE_STRING, schema.Fields["tags"].Subtype.Type)
+	assert.Equal(t, TYPE_ARRAY, schema.Fields["values"].Type)
+	assert.False(t, schema.Fields["values"].NotNull)
+	assert.Equal(t, TYPE_FLOAT64, schema.Fields["values"].Subtype.Type)
+	assert.Equal(t, TYPE_ANY, schema.Fields["empty"].Subtype.Type)
+
+	attrs := schema.Fields["attributes"]
+	assert.Equal(t, TYPE_MAP, attrs.Type)
+	assert.True(t, attrs.Schema.Fields["title"].NotNull)
+	assert.False(t, attrs.Schema.Fields["team"].NotNull)
+
+	attachments := schema.Fields["attachments"]
+	assert.Equal(t, TYPE_MAP, attachments.Subtype.Type)
+	assert.Equal(t, TYPE_STRING, attachments.Subtype.Schema.Fields["filename"].Type)
+	assert.Equal(t, TYPE_INT64, attachments.Subtype.Schema.Fields["size"].Type)
+	assert.False(t, attachments.Subtype.Schema.Fields["size"].NotNull)
+
+	// Inferred schema should accept all samples
+	for _, record := range records {
+		assert.Nil(t, schema.Validate(record))
+	}
+}
+
+func TestMergeSchema(t *testing.T) {
+
+	a := NewSchema()
+	UnmarshalJSON([]byte(`{
+	"id": { "type": "uint", "notNull": true },
+	"name": { "type": "string", "notNull": true },
+	"attributes": {
+		"type": "map",
+		"fields": {
+			"title": { "type": "string" }
+		}
+	}
+}`), a)
+
+	b := NewSchema()
+	UnmarshalJSON([]byte(`{
+	"id": { "type": "int", "notNull": true },
+	"name": { "type": "array", "subtype": "string" },
+	"attributes": { "type": "string" }
+}`), b)
+
+	s := MergeSchema(a, b)
+
+	assert.Equal(t, TYPE_INT64, s.Fields["id"].Type)
+	assert.True(t, s.Fields["id"].NotNull)
+	assert.Equal(t, TYPE_ANY, s.Fields["name"].Type)
+	assert.False(t, s.Fields["name"].NotNull)
+	assert.Equal(t, TYPE_ANY, s.Fields["attributes"].Type)
+
+	// Original schemas should not be changed
+	assert.Equal(t, TYPE_UINT64, a.Fields["id"].Type)
+	assert.Equal(t, TYPE_STRING, b.Fields["attributes"].Type)
+}
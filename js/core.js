@@ -212,7 +212,111 @@ function execute(source, script) {
   return result;
 }
 
+// Durations are represented as milliseconds which can be used with Date directly
+const Duration = {
+  Nanosecond: 0.000001,
+  Microsecond: 0.001,
+  Millisecond: 1,
+  Second: 1000,
+  Minute: 60000,
+  Hour: 3600000,
+  Day: 86400000,
+
+  // Parse Go duration string (1h30m) or ISO 8601 duration (PT1H30M) to milliseconds
+  parse: function(value) {
+
+    if (typeof value === 'number') {
+      return value;
+    }
+
+    let str = String(value).trim();
+
+    let iso = str.match(/^([-+])?P(?:([\d.,]+)Y)?(?:([\d.,]+)M)?(?:([\d.,]+)W)?(?:([\d.,]+)D)?(?:T(?:([\d.,]+)H)?(?:([\d.,]+)M)?(?:([\d.,]+)S)?)?$/);
+    if (iso) {
+      let units = [ 365 * Duration.Day, 30 * Duration.Day, 7 * Duration.Day, Duration.Day, Duration.Hour, Duration.Minute, Duration.Second ];
+      let result = 0;
+      units.forEach((unit, i) => {
+        if (iso[i + 2] !== undefined) {
+          result += parseFloat(iso[i + 2].replace(',', '.')) * unit;
+        }
+      });
+
+      return iso[1] === '-' ? -result : result;
+    }
+
+    let units = {
+      ns: Duration.Nanosecond,
+      us: Duration.Microsecond,
+      'µs': Duration.Microsecond,
+      ms: Duration.Millisecond,
+      s: Duration.Second,
+      m: Duration.Minute,
+      h: Duration.Hour,
+    };
+
+    let sign = 1;
+    if (str[0] === '-' || str[0] === '+') {
+      sign = str[0] === '-' ? -1 : 1;
+      str = str.substring(1);
+    }
+
+    let re = /([\d.]+)(ns|us|µs|ms|s|m|h)/y;
+    let result = 0;
+    let matched = 0;
+    let m;
+    while ((m = re.exec(str)) !== null) {
+      result += parseFloat(m[1]) * units[m[2]];
+      matched = re.lastIndex;
+    }
+
+    if (matched === 0 || matched !== str.length) {
+      return NaN;
+    }
+
+    return sign * result;
+  },
+
+  // Format milliseconds as Go duration string
+  format: function(ms) {
+
+    if (ms === 0) {
+      return '0s';
+    }
+
+    let sign = ms < 0 ? '-' : '';
+    let value = Math.abs(ms);
+
+    if (value < Duration.Second) {
+      return sign + parseFloat(value.toFixed(6)) + 'ms';
+    }
+
+    let hours = Math.floor(value / Duration.Hour);
+    let minutes = Math.floor((value % Duration.Hour) / Duration.Minute);
+    let seconds = parseFloat(((value % Duration.Minute) / Duration.Second).toFixed(9));
+
+    let str = seconds + 's';
+    if (hours > 0) {
+      str = hours + 'h' + minutes + 'm' + str;
+    } else if (minutes > 0) {
+      str = minutes + 'm' + str;
+    }
+
+    return sign + str;
+  },
+
+  // Add duration to date and return a new Date
+  add: function(date, duration) {
+    return new Date(new Date(date).getTime() + Duration.parse(duration));
+  },
+
+  // Get duration from one date to another
+  between: function(from, to) {
+    return new Date(to).getTime() - new Date(from).getTime();
+  },
+};
+
 module.exports = {
+  Duration: Duration,
   getUpdates: getUpdates,
   getValue: getValue,
   watch: watch,
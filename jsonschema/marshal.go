@@ -0,0 +1,259 @@
+package jsonschema
+
+import (
+	"math"
+	"sort"
+
+	"github.com/BrobridgeOrg/schemer"
+	"github.com/BrobridgeOrg/schemer/types"
+)
+
+const SchemaURI = "https://json-schema.org/draft/2020-12/schema"
+
+// Prefix of reference to shared definition
+const refPrefix = "#/$defs/"
+
+var integerBounds = map[schemer.ValueType][2]int64{
+	schemer.TYPE_INT8:   {math.MinInt8, math.MaxInt8},
+	schemer.TYPE_INT16:  {math.MinInt16, math.MaxInt16},
+	schemer.TYPE_INT32:  {math.MinInt32, math.MaxInt32},
+	schemer.TYPE_UINT8:  {0, math.MaxUint8},
+	schemer.TYPE_UINT16: {0, math.MaxUint16},
+	schemer.TYPE_UINT32: {0, math.MaxUint32},
+}
+
+// Content encodings of binary encodings
+var contentEncodings = map[string]string{
+	types.BINARY_ENCODING_BASE64:    "base64",
+	types.BINARY_ENCODING_BASE64URL: "base64url",
+	types.BINARY_ENCODING_HEX:       "base16",
+}
+
+// MarshalJSON generates JSON Schema document from schema
+func MarshalJSON(s *schemer.Schema) ([]byte, error) {
+
+	doc, err := Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(doc)
+}
+
+// Marshal converts schema to JSON Schema document
+func Marshal(s *schemer.Schema) (map[string]interface{}, error) {
+
+	doc, err := convertSchema("", s)
+	if err != nil {
+		return nil, err
+	}
+
+	doc["$schema"] = SchemaURI
+
+	if s != nil && len(s.Defs) > 0 {
+		defs := make(map[string]interface{}, len(s.Defs))
+		for name, def := range s.Defs {
+
+			// Convert body of shared definition instead of reference
+			body := *def
+			body.Ref = ""
+
+			d, err := convertToJSONSchema(joinPath("$defs", name), &body)
+			if err != nil {
+				return nil, err
+			}
+
+			defs[name] = d
+		}
+
+		doc["$defs"] = defs
+	}
+
+	return doc, nil
+}
+
+func convertSchema(path string, s *schemer.Schema) (map[string]interface{}, error) {
+
+	properties := make(map[string]interface{})
+	required := make([]string, 0)
+
+	if s != nil {
+		for name, def := range s.Fields {
+
+			// Skip internal fields
+			if len(name) > 0 && name[0] == '$' {
+				continue
+			}
+
+			prop, err := convertToJSONSchema(joinPath(path, name), def)
+			if err != nil {
+				return nil, err
+			}
+
+			properties[name] = prop
+
+			if def.NotNull {
+				required = append(required, name)
+			}
+		}
+	}
+
+	sort.Strings(required)
+
+	doc := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+
+	if len(required) > 0 {
+		doc["required"] = required
+	}
+
+	return doc, nil
+}
+
+func convertToJSONSchema(path string, def *schemer.Definition) (map[string]interface{}, error) {
+
+	if len(def.Ref) > 0 {
+		return map[string]interface{}{
+			"$ref": refPrefix + def.Ref,
+		}, nil
+	}
+
+	doc := make(map[string]interface{})
+
+	for _, keyword := range propKeywords {
+		if v, ok := def.Props[keyword]; ok {
+			doc[keyword] = v
+		}
+	}
+
+	if _, ok := doc["default"]; !ok && def.Default != nil {
+		doc["default"] = def.Default
+	}
+
+	var t string
+	switch def.Type {
+	case schemer.TYPE_ANY:
+		// Any value is allowed
+		return doc, nil
+	case schemer.TYPE_UNION:
+
+		alternatives := make([]interface{}, 0, len(def.Types)+1)
+		for _, d := range def.Types {
+
+			alternative, err := convertToJSONSchema(path, d)
+			if err != nil {
+				return nil, err
+			}
+
+			alternatives = append(alternatives, alternative)
+		}
+
+		if !def.NotNull {
+			alternatives = append(alternatives, map[string]interface{}{
+				"type": "null",
+			})
+		}
+
+		doc["oneOf"] = alternatives
+
+		return doc, nil
+	case schemer.TYPE_STRING:
+		t = "string"
+	case schemer.TYPE_BINARY:
+		t = "string"
+
+		// Raw bytes without encoding
+		doc["format"] = "binary"
+		if b, ok := def.Info.(*types.Binary); ok {
+			if encoding, ok := contentEncodings[b.Encoding]; ok {
+				delete(doc, "format")
+				doc["contentEncoding"] = encoding
+			}
+		}
+	case schemer.TYPE_INT64:
+		t = "integer"
+	case schemer.TYPE_UINT64:
+		t = "integer"
+		if _, ok := doc["minimum"]; !ok {
+			doc["minimum"] = 0
+		}
+	case schemer.TYPE_INT8, schemer.TYPE_INT16, schemer.TYPE_INT32,
+		schemer.TYPE_UINT8, schemer.TYPE_UINT16, schemer.TYPE_UINT32:
+		t = "integer"
+
+		// Range of fixed-width integer
+		bounds := integerBounds[def.Type]
+		if _, ok := doc["minimum"]; !ok {
+			doc["minimum"] = bounds[0]
+		}
+
+		if _, ok := doc["maximum"]; !ok {
+			doc["maximum"] = bounds[1]
+		}
+	case schemer.TYPE_FLOAT64, schemer.TYPE_FLOAT32, schemer.TYPE_DECIMAL:
+		t = "number"
+	case schemer.TYPE_BOOLEAN:
+		t = "boolean"
+	case schemer.TYPE_TIME:
+		t = "string"
+		doc["format"] = "date-time"
+	case schemer.TYPE_DATE:
+		t = "string"
+		doc["format"] = "date"
+	case schemer.TYPE_TIMEOFDAY:
+		t = "string"
+		doc["format"] = "time"
+	case schemer.TYPE_DURATION:
+		t = "string"
+		doc["format"] = "duration"
+	case schemer.TYPE_ENUM:
+		t = "string"
+		if e, ok := def.Info.(*types.Enum); ok {
+			symbols := make([]interface{}, len(e.Symbols))
+			for i, symbol := range e.Symbols {
+				symbols[i] = symbol
+			}
+
+			doc["enum"] = symbols
+		}
+	case schemer.TYPE_UUID:
+		t = "string"
+		doc["format"] = "uuid"
+	case schemer.TYPE_ARRAY:
+		t = "array"
+
+		if def.Subtype != nil {
+			items, err := convertToJSONSchema(path+"[]", def.Subtype)
+			if err != nil {
+				return nil, err
+			}
+
+			doc["items"] = items
+		}
+
+	case schemer.TYPE_MAP:
+		t = "object"
+
+		obj, err := convertSchema(path, def.Schema)
+		if err != nil {
+			return nil, err
+		}
+
+		for key, value := range obj {
+			doc[key] = value
+		}
+
+	default:
+		return nil, wrapError(path, ErrUnsupportedType, def.Type)
+	}
+
+	if def.NotNull {
+		doc["type"] = t
+	} else {
+		doc["type"] = []interface{}{t, "null"}
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,99 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/BrobridgeOrg/schemer"
+	"github.com/BrobridgeOrg/schemer/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalJSON(t *testing.T) {
+
+	source := `{
+	"id": { "type": "uint", "notNull": true },
+	"name": { "type": "string", "notNull": true, "maxLength": 32 },
+	"score": { "type": "float" },
+	"enabled": { "type": "bool", "default": true },
+	"createdAt": { "type": "time" },
+	"avatar": { "type": "binary" },
+	"photo": { "type": "binary", "encoding": "base64" },
+	"checksum": { "type": "binary", "encoding": "hex" },
+	"tags": {
+		"type": "array",
+		"subtype": "string"
+	},
+	"attributes": {
+		"type": "map",
+		"notNull": true,
+		"fields": {
+			"title": { "type": "string", "notNull": true },
+			"team": { "type": "string" }
+		}
+	},
+	"attached": { "type": "any" }
+}`
+
+	schema := schemer.NewSchema()
+	err := schemer.UnmarshalJSON([]byte(source), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	data, err := MarshalJSON(schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	var doc map[string]interface{}
+	json.Unmarshal(data, &doc)
+
+	assert.Equal(t, SchemaURI, doc["$schema"])
+	assert.Equal(t, "object", doc["type"])
+	assert.Equal(t, []interface{}{"attributes", "id", "name"}, doc["required"])
+
+	props := doc["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": "integer", "minimum": float64(0)}, props["id"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "maxLength": float64(32)}, props["name"])
+	assert.Equal(t, map[string]interface{}{"type": []interface{}{"number", "null"}}, props["score"])
+	assert.Equal(t, map[string]interface{}{"type": []interface{}{"boolean", "null"}, "default": true}, props["enabled"])
+	assert.Equal(t, map[string]interface{}{"type": []interface{}{"string", "null"}, "format": "date-time"}, props["createdAt"])
+	assert.Equal(t, map[string]interface{}{"type": []interface{}{"string", "null"}, "format": "binary"}, props["avatar"])
+	assert.Equal(t, map[string]interface{}{"type": []interface{}{"string", "null"}, "contentEncoding": "base64"}, props["photo"])
+	assert.Equal(t, map[string]interface{}{"type": []interface{}{"string", "null"}, "contentEncoding": "base16"}, props["checksum"])
+	assert.Equal(t, map[string]interface{}{}, props["attached"])
+
+	tags := props["tags"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"array", "null"}, tags["type"])
+	assert.Equal(t, map[string]interface{}{"type": []interface{}{"string", "null"}}, tags["items"])
+
+	attrs := props["attributes"].(map[string]interface{})
+	assert.Equal(t, "object", attrs["type"])
+	assert.Equal(t, []interface{}{"title"}, attrs["required"])
+	assert.Contains(t, attrs["properties"], "team")
+
+	// Round trip
+	restored := schemer.NewSchema()
+	err = UnmarshalJSON(data, restored)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, schemer.TYPE_INT64, restored.Fields["id"].Type)
+	assert.True(t, restored.Fields["id"].NotNull)
+	assert.Equal(t, schemer.TYPE_TIME, restored.Fields["createdAt"].Type)
+	assert.Equal(t, schemer.TYPE_BINARY, restored.Fields["avatar"].Type)
+	assert.Equal(t, types.BINARY_ENCODING_RAW, restored.Fields["avatar"].Info.(*types.Binary).Encoding)
+	assert.Equal(t, types.BINARY_ENCODING_BASE64, restored.Fields["photo"].Info.(*types.Binary).Encoding)
+	assert.Equal(t, types.BINARY_ENCODING_HEX, restored.Fields["checksum"].Info.(*types.Binary).Encoding)
+
+	// Encoded string is decoded by restored schema
+	result := restored.Normalize(map[string]interface{}{
+		"photo":    "AQID",
+		"checksum": "010203",
+	})
+	assert.Equal(t, []byte{1, 2, 3}, result["photo"])
+	assert.Equal(t, []byte{1, 2, 3}, result["checksum"])
+	assert.Equal(t, schemer.TYPE_STRING, restored.Fields["tags"].Subtype.Type)
+	assert.True(t, restored.Fields["attributes"].Schema.Fields["title"].NotNull)
+}
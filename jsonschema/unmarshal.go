@@ -0,0 +1,429 @@
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/BrobridgeOrg/schemer"
+	"github.com/BrobridgeOrg/schemer/types"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+var (
+	ErrInvalidDocument     = errors.New("Invalid JSON Schema document")
+	ErrUnsupportedKeyword  = errors.New("Unsupported keyword")
+	ErrUnsupportedType     = errors.New("Unsupported type")
+	ErrInvalidTypeKeyword  = errors.New("Invalid type keyword")
+	ErrInvalidItemsKeyword = errors.New("Invalid items keyword")
+)
+
+// Keywords which cannot be represented by schemer definition
+var unsupportedKeywords = []string{
+	"allOf",
+	"anyOf",
+	"oneOf",
+	"not",
+	"if",
+	"then",
+	"else",
+	"prefixItems",
+	"patternProperties",
+	"dependentSchemas",
+}
+
+// Keywords which are supported with specific value only because schemer has no equivalent constraint
+var restrictedKeywords = map[string]interface{}{
+	"uniqueItems":          false,
+	"additionalProperties": true,
+}
+
+// Keywords which are kept as properties of definition
+var propKeywords = []string{
+	"minimum",
+	"maximum",
+	"exclusiveMinimum",
+	"exclusiveMaximum",
+	"multipleOf",
+	"minLength",
+	"maxLength",
+	"pattern",
+	"enum",
+	"minItems",
+	"maxItems",
+	"default",
+	"title",
+	"description",
+}
+
+// Binary encodings of content encodings
+var binaryEncodings = map[string]string{
+	"base64":    types.BINARY_ENCODING_BASE64,
+	"base64url": types.BINARY_ENCODING_BASE64URL,
+	"base16":    types.BINARY_ENCODING_HEX,
+}
+
+// UnmarshalJSON parses JSON Schema document and stores fields into schema
+func UnmarshalJSON(source []byte, s *schemer.Schema) error {
+
+	var doc map[string]interface{}
+	err := json.Unmarshal(source, &doc)
+	if err != nil {
+		return err
+	}
+
+	return Unmarshal(doc, s)
+}
+
+// Unmarshal converts JSON Schema document with object type to schema
+func Unmarshal(doc map[string]interface{}, s *schemer.Schema) error {
+
+	fields, err := convertProperties("", doc)
+	if err != nil {
+		return err
+	}
+
+	// Shared definitions
+	if v, ok := doc["$defs"]; ok {
+
+		defs, ok := v.(map[string]interface{})
+		if !ok {
+			return wrapError("$defs", ErrInvalidDocument, v)
+		}
+
+		converted := make(map[string]interface{}, len(defs))
+		for name, d := range defs {
+
+			defDoc, ok := d.(map[string]interface{})
+			if !ok {
+				return wrapError(joinPath("$defs", name), ErrInvalidDocument, d)
+			}
+
+			def, _, err := convertDefinition(joinPath("$defs", name), defDoc)
+			if err != nil {
+				return err
+			}
+
+			converted[name] = def
+		}
+
+		fields["$defs"] = converted
+	}
+
+	return schemer.Unmarshal(fields, s)
+}
+
+func wrapError(path string, err error, v interface{}) error {
+
+	if len(path) == 0 {
+		path = "#"
+	}
+
+	return fmt.Errorf("%w: %v (%s)", err, v, path)
+}
+
+func joinPath(parent string, key string) string {
+
+	if len(parent) == 0 {
+		return key
+	}
+
+	return parent + "." + key
+}
+
+func convertProperties(path string, doc map[string]interface{}) (map[string]interface{}, error) {
+
+	t, _, err := getType(path, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	if t != "object" {
+		return nil, wrapError(path, ErrInvalidDocument, t)
+	}
+
+	err = checkKeywords(path, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	props, ok := doc["properties"].(map[string]interface{})
+	if !ok {
+		return nil, wrapError(path, ErrInvalidDocument, "properties")
+	}
+
+	// Required fields
+	required := make(map[string]bool)
+	if r, ok := doc["required"].([]interface{}); ok {
+		for _, name := range r {
+			if n, ok := name.(string); ok {
+				required[n] = true
+			}
+		}
+	}
+
+	fields := make(map[string]interface{}, len(props))
+	for name, prop := range props {
+
+		p, ok := prop.(map[string]interface{})
+		if !ok {
+			return nil, wrapError(joinPath(path, name), ErrInvalidDocument, prop)
+		}
+
+		def, nullable, err := convertDefinition(joinPath(path, name), p)
+		if err != nil {
+			return nil, err
+		}
+
+		if required[name] && !nullable {
+			def["notNull"] = true
+		}
+
+		fields[name] = def
+	}
+
+	return fields, nil
+}
+
+// checkKeywords returns error if document contains keywords which cannot be represented
+func checkKeywords(path string, doc map[string]interface{}) error {
+
+	for _, keyword := range unsupportedKeywords {
+		if _, ok := doc[keyword]; ok {
+			return wrapError(path, ErrUnsupportedKeyword, keyword)
+		}
+	}
+
+	for keyword, allowed := range restrictedKeywords {
+		if v, ok := doc[keyword]; ok && v != allowed {
+			return wrapError(path, ErrUnsupportedKeyword, keyword)
+		}
+	}
+
+	return nil
+}
+
+// convertConstraints converts keywords which have different form in schemer definition
+func convertConstraints(path string, doc map[string]interface{}, def map[string]interface{}) error {
+
+	// Boolean form of draft 4 makes minimum and maximum exclusive
+	bounds := map[string]string{
+		"exclusiveMinimum": "minimum",
+		"exclusiveMaximum": "maximum",
+	}
+
+	for keyword, bound := range bounds {
+
+		exclusive, ok := def[keyword].(bool)
+		if !ok {
+			continue
+		}
+
+		delete(def, keyword)
+
+		if v, ok := def[bound]; ok && exclusive {
+			def[keyword] = v
+			delete(def, bound)
+		}
+	}
+
+	// Constant is an enum with single value
+	if v, ok := doc["const"]; ok {
+
+		// Only scalar value can be compared
+		switch v.(type) {
+		case nil, map[string]interface{}, []interface{}:
+			return wrapError(path, ErrUnsupportedKeyword, "const")
+		}
+
+		if _, ok := def["enum"]; ok {
+			return wrapError(path, ErrUnsupportedKeyword, "const")
+		}
+
+		def["enum"] = []interface{}{v}
+	}
+
+	return nil
+}
+
+// getType returns type name and whether null is allowed
+func getType(path string, doc map[string]interface{}) (string, bool, error) {
+
+	nullable := false
+	if v, ok := doc["nullable"].(bool); ok {
+		nullable = v
+	}
+
+	t, ok := doc["type"]
+	if !ok {
+
+		// Object is implied by properties
+		if _, ok := doc["properties"]; ok {
+			return "object", nullable, nil
+		}
+
+		return "", true, nil
+	}
+
+	switch d := t.(type) {
+	case string:
+		if d == "null" {
+			return "", false, wrapError(path, ErrUnsupportedType, d)
+		}
+
+		return d, nullable, nil
+	case []interface{}:
+
+		name := ""
+		for _, e := range d {
+
+			n, ok := e.(string)
+			if !ok {
+				return "", false, wrapError(path, ErrInvalidTypeKeyword, t)
+			}
+
+			if n == "null" {
+				nullable = true
+				continue
+			}
+
+			// Multiple types cannot be represented
+			if len(name) > 0 {
+				return "", false, wrapError(path, ErrUnsupportedType, t)
+			}
+
+			name = n
+		}
+
+		if len(name) == 0 {
+			return "", false, wrapError(path, ErrUnsupportedType, t)
+		}
+
+		return name, nullable, nil
+	}
+
+	return "", false, wrapError(path, ErrInvalidTypeKeyword, t)
+}
+
+func convertDefinition(path string, doc map[string]interface{}) (map[string]interface{}, bool, error) {
+
+	// Only references to shared definitions are supported
+	if ref, ok := doc["$ref"]; ok {
+
+		name, _ := ref.(string)
+		if !strings.HasPrefix(name, refPrefix) || len(doc) > 1 {
+			return nil, false, wrapError(path, ErrUnsupportedKeyword, "$ref")
+		}
+
+		return map[string]interface{}{
+			"$ref": name,
+		}, false, nil
+	}
+
+	err := checkKeywords(path, doc)
+	if err != nil {
+		return nil, false, err
+	}
+
+	t, nullable, err := getType(path, doc)
+	if err != nil {
+		return nil, false, err
+	}
+
+	def := make(map[string]interface{})
+
+	for _, keyword := range propKeywords {
+		if v, ok := doc[keyword]; ok {
+			def[keyword] = v
+		}
+	}
+
+	err = convertConstraints(path, doc, def)
+	if err != nil {
+		return nil, false, err
+	}
+
+	switch t {
+	case "":
+		def["type"] = "any"
+	case "string":
+
+		format, _ := doc["format"].(string)
+		encoding, _ := doc["contentEncoding"].(string)
+
+		switch {
+		case format == "date-time":
+			def["type"] = "time"
+		case format == "date":
+			def["type"] = "date"
+		case format == "time":
+			def["type"] = "timeofday"
+		case format == "duration":
+			def["type"] = "duration"
+		case format == "uuid":
+			def["type"] = "uuid"
+		case format == "byte":
+			def["type"] = "binary"
+			def["encoding"] = types.BINARY_ENCODING_BASE64
+		case len(binaryEncodings[encoding]) > 0:
+			def["type"] = "binary"
+			def["encoding"] = binaryEncodings[encoding]
+		case format == "binary":
+			def["type"] = "binary"
+		default:
+			def["type"] = "string"
+		}
+
+	case "integer":
+		def["type"] = "int"
+	case "number":
+		def["type"] = "float"
+	case "boolean":
+		def["type"] = "bool"
+	case "array":
+
+		def["type"] = "array"
+
+		items, ok := doc["items"]
+		if !ok {
+			def["subtype"] = "any"
+			break
+		}
+
+		// Tuple validation is not supported
+		itemsDoc, ok := items.(map[string]interface{})
+		if !ok {
+			return nil, false, wrapError(path, ErrInvalidItemsKeyword, items)
+		}
+
+		subtype, _, err := convertDefinition(path+"[]", itemsDoc)
+		if err != nil {
+			return nil, false, err
+		}
+
+		def["subtype"] = subtype
+
+	case "object":
+
+		// Object without properties can contain anything
+		if _, ok := doc["properties"]; !ok {
+			def["type"] = "any"
+			break
+		}
+
+		fields, err := convertProperties(path, doc)
+		if err != nil {
+			return nil, false, err
+		}
+
+		def["type"] = "map"
+		def["fields"] = fields
+
+	default:
+		return nil, false, wrapError(path, ErrUnsupportedType, t)
+	}
+
+	return def, nullable, nil
+}
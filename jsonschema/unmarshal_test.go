@@ -0,0 +1,195 @@
+package jsonschema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/BrobridgeOrg/schemer"
+	"github.com/BrobridgeOrg/schemer/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalJSON(t *testing.T) {
+
+	source := `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"required": [ "id", "name", "nickname" ],
+	"properties": {
+		"id": { "type": "integer", "minimum": 1 },
+		"name": { "type": "string", "maxLength": 32 },
+		"nickname": { "type": [ "string", "null" ] },
+		"score": { "type": "number" },
+		"enabled": { "type": "boolean", "default": true },
+		"createdAt": { "type": "string", "format": "date-time" },
+		"avatar": { "type": "string", "contentEncoding": "base64" },
+		"tags": {
+			"type": "array",
+			"items": { "type": "string" }
+		},
+		"attributes": {
+			"type": "object",
+			"required": [ "title" ],
+			"properties": {
+				"title": { "type": "string" },
+				"team": { "type": "string", "nullable": true }
+			}
+		},
+		"extra": { "type": "object" },
+		"attached": {}
+	}
+}`
+
+	schema := schemer.NewSchema()
+	err := UnmarshalJSON([]byte(source), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, schemer.TYPE_INT64, schema.Fields["id"].Type)
+	assert.True(t, schema.Fields["id"].NotNull)
+	assert.NotNil(t, schema.Fields["id"].Constraint)
+	assert.Equal(t, schemer.TYPE_STRING, schema.Fields["name"].Type)
+	assert.True(t, schema.Fields["name"].NotNull)
+	assert.Equal(t, schemer.TYPE_STRING, schema.Fields["nickname"].Type)
+	assert.False(t, schema.Fields["nickname"].NotNull)
+	assert.Equal(t, schemer.TYPE_FLOAT64, schema.Fields["score"].Type)
+	assert.Equal(t, schemer.TYPE_BOOLEAN, schema.Fields["enabled"].Type)
+	assert.Equal(t, true, schema.Fields["enabled"].Default)
+	assert.Equal(t, schemer.TYPE_TIME, schema.Fields["createdAt"].Type)
+	assert.IsType(t, &types.Time{}, schema.Fields["createdAt"].Info)
+	assert.Equal(t, schemer.TYPE_BINARY, schema.Fields["avatar"].Type)
+	assert.Equal(t, types.BINARY_ENCODING_BASE64, schema.Fields["avatar"].Info.(*types.Binary).Encoding)
+	assert.Equal(t, schemer.TYPE_ARRAY, schema.Fields["tags"].Type)
+	assert.Equal(t, schemer.TYPE_STRING, schema.Fields["tags"].Subtype.Type)
+	assert.Equal(t, schemer.TYPE_ANY, schema.Fields["extra"].Type)
+	assert.Equal(t, schemer.TYPE_ANY, schema.Fields["attached"].Type)
+
+	attrs := schema.Fields["attributes"]
+	assert.Equal(t, schemer.TYPE_MAP, attrs.Type)
+	assert.True(t, attrs.Schema.Fields["title"].NotNull)
+	assert.False(t, attrs.Schema.Fields["team"].NotNull)
+}
+
+func TestUnmarshalJSON_Unsupported(t *testing.T) {
+
+	testCases := map[string]error{
+		`{ "type": "string" }`: ErrInvalidDocument,
+		`{ "properties": { "a": { "oneOf": [ { "type": "string" } ] } } }`:                              ErrUnsupportedKeyword,
+		`{ "properties": { "a": { "type": [ "string", "integer" ] } } }`:                                ErrUnsupportedType,
+		`{ "properties": { "a": { "type": "null" } } }`:                                                 ErrUnsupportedType,
+		`{ "properties": { "a": { "type": "array", "items": [ {}, {} ] } } }`:                           ErrInvalidItemsKeyword,
+		`{ "properties": { "a": { "properties": { "b": { "$ref": "#/x" } } } } }`:                       ErrUnsupportedKeyword,
+		`{ "properties": { "a": { "type": "array", "uniqueItems": true } } }`:                           ErrUnsupportedKeyword,
+		`{ "properties": { "a": { "type": "string" } }, "additionalProperties": false }`:                ErrUnsupportedKeyword,
+		`{ "properties": { "a": { "properties": {}, "additionalProperties": { "type": "string" } } } }`: ErrUnsupportedKeyword,
+		`{ "properties": { "a": { "const": { "b": 1 } } } }`:                                            ErrUnsupportedKeyword,
+		`{ "properties": { "a": { "type": "string", "const": "x", "enum": [ "x", "y" ] } } }`:           ErrUnsupportedKeyword,
+	}
+
+	for source, expected := range testCases {
+		err := UnmarshalJSON([]byte(source), schemer.NewSchema())
+		assert.True(t, errors.Is(err, expected), source)
+	}
+
+	err := UnmarshalJSON([]byte(`{ "properties": { "a": { "properties": { "b": { "anyOf": [] } } } } }`), schemer.NewSchema())
+	assert.Contains(t, err.Error(), "a.b")
+}
+
+func TestUnmarshalJSON_Constraints(t *testing.T) {
+
+	source := `{
+	"type": "object",
+	"additionalProperties": true,
+	"properties": {
+		"score": { "type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 100 },
+		"level": { "type": "integer", "minimum": 1, "exclusiveMinimum": true, "maximum": 5 },
+		"kind": { "type": "string", "const": "user" },
+		"tags": { "type": "array", "items": { "type": "string" }, "uniqueItems": false }
+	}
+}`
+
+	schema := schemer.NewSchema()
+	err := UnmarshalJSON([]byte(source), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	violations := schema.Validate(map[string]interface{}{
+		"score": float64(0),
+		"level": int64(1),
+		"kind":  "admin",
+	})
+
+	if assert.Len(t, violations, 3) {
+		assert.Equal(t, "kind", violations[0].Path)
+		assert.Equal(t, schemer.VIOLATION_ENUM, violations[0].Reason)
+		assert.Equal(t, "level", violations[1].Path)
+		assert.Equal(t, schemer.VIOLATION_OUT_OF_RANGE, violations[1].Reason)
+		assert.Equal(t, "score", violations[2].Path)
+		assert.Equal(t, schemer.VIOLATION_OUT_OF_RANGE, violations[2].Reason)
+	}
+
+	violations = schema.Validate(map[string]interface{}{
+		"score": float64(99.5),
+		"level": int64(2),
+		"kind":  "user",
+	})
+
+	assert.Len(t, violations, 0)
+
+	// Exclusive bounds are kept after converting back
+	doc, err := Marshal(schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	score := doc["properties"].(map[string]interface{})["score"].(map[string]interface{})
+	assert.EqualValues(t, 0, score["exclusiveMinimum"])
+	assert.EqualValues(t, 100, score["exclusiveMaximum"])
+
+	level := doc["properties"].(map[string]interface{})["level"].(map[string]interface{})
+	assert.EqualValues(t, 1, level["exclusiveMinimum"])
+	assert.NotContains(t, level, "minimum")
+}
+
+func TestUnmarshalJSON_Refs(t *testing.T) {
+
+	source := `{
+	"$defs": {
+		"node": {
+			"type": "object",
+			"properties": {
+				"name": { "type": "string" },
+				"children": { "type": "array", "items": { "$ref": "#/$defs/node" } }
+			}
+		}
+	},
+	"type": "object",
+	"required": [ "tree" ],
+	"properties": {
+		"tree": { "$ref": "#/$defs/node" }
+	}
+}`
+
+	s := schemer.NewSchema()
+	err := UnmarshalJSON([]byte(source), s)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	tree := s.Fields["tree"]
+	assert.Equal(t, schemer.TYPE_MAP, tree.Type)
+	assert.True(t, tree.NotNull)
+	assert.Equal(t, s.Defs["node"].Schema, tree.Schema)
+	assert.Equal(t, s.Defs["node"], tree.Schema.Fields["children"].Subtype)
+
+	// Marshal references back
+	doc, err := Marshal(s)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, map[string]interface{}{"$ref": "#/$defs/node"}, doc["properties"].(map[string]interface{})["tree"])
+	assert.Contains(t, doc["$defs"], "node")
+}
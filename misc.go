@@ -39,3 +39,30 @@ func parsePathEntry(entry string) (string, int) {
 
 	return key, index
 }
+
+// copyValue returns a deep copy of maps, arrays and binary so shared values are never modified
+func copyValue(data interface{}) interface{} {
+
+	switch d := data.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(d))
+		for k, v := range d {
+			m[k] = copyValue(v)
+		}
+
+		return m
+	case []interface{}:
+		arr := make([]interface{}, len(d))
+		for i, v := range d {
+			arr[i] = copyValue(v)
+		}
+
+		return arr
+	case []byte:
+		b := make([]byte, len(d))
+		copy(b, d)
+		return b
+	}
+
+	return data
+}
@@ -0,0 +1,217 @@
+package schemer
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BrobridgeOrg/schemer/types"
+)
+
+// Bit sizes of integer types
+var integerBitSizes = map[ValueType]uint{
+	TYPE_INT8:   8,
+	TYPE_INT16:  16,
+	TYPE_INT32:  32,
+	TYPE_INT64:  64,
+	TYPE_UINT8:  8,
+	TYPE_UINT16: 16,
+	TYPE_UINT32: 32,
+	TYPE_UINT64: 64,
+}
+
+func isSignedIntegerType(t ValueType) bool {
+	switch t {
+	case TYPE_INT8, TYPE_INT16, TYPE_INT32, TYPE_INT64:
+		return true
+	}
+
+	return false
+}
+
+func isUnsignedIntegerType(t ValueType) bool {
+	switch t {
+	case TYPE_UINT8, TYPE_UINT16, TYPE_UINT32, TYPE_UINT64:
+		return true
+	}
+
+	return false
+}
+
+// isWiderIntegerType returns true if all values of writer type can be represented by reader type
+func isWiderIntegerType(reader ValueType, writer ValueType) bool {
+
+	readerBits, ok := integerBitSizes[reader]
+	if !ok {
+		return false
+	}
+
+	writerBits, ok := integerBitSizes[writer]
+	if !ok {
+		return false
+	}
+
+	if isUnsignedIntegerType(reader) {
+		return isUnsignedIntegerType(writer) && writerBits <= readerBits
+	}
+
+	if isUnsignedIntegerType(writer) {
+		return writerBits < readerBits
+	}
+
+	return writerBits <= readerBits
+}
+
+// getExactIntegerValue converts value to integer without rounding. ErrPrecisionLoss will be returned if value has fraction.
+func getExactIntegerValue(def *Definition, data interface{}) (*big.Int, error) {
+
+	switch d := data.(type) {
+	case int64:
+		return big.NewInt(d), nil
+	case uint64:
+		return new(big.Int).SetUint64(d), nil
+	case float64:
+		if math.IsNaN(d) || math.IsInf(d, 0) {
+			return nil, ErrOverflow
+		}
+
+		if d != math.Trunc(d) {
+			return nil, ErrPrecisionLoss
+		}
+
+		result, _ := big.NewFloat(d).Int(nil)
+		return result, nil
+	case string:
+		// Same as int64, only integers in base 10 are accepted
+		result, ok := new(big.Int).SetString(d, 10)
+		if !ok {
+			return nil, ErrUnparsable
+		}
+
+		return result, nil
+	case types.DecimalValue:
+		r, _ := new(big.Rat).SetString(d.String())
+		if !r.IsInt() {
+			return nil, ErrPrecisionLoss
+		}
+
+		return r.Num(), nil
+	case bool, time.Time, types.Date, types.TimeOfDay, time.Duration, types.EnumValue:
+		result, err := getIntegerValue(def, d)
+		if err != nil {
+			return nil, err
+		}
+
+		return big.NewInt(result), nil
+	}
+
+	return nil, ErrInvalidType
+}
+
+// getSizedIntegerValue converts value to signed integer with specific bit size. ErrOverflow will be returned if value is out of range.
+func getSizedIntegerValue(def *Definition, data interface{}) (interface{}, error) {
+
+	n, err := getExactIntegerValue(def, data)
+	if err != nil {
+		return nil, err
+	}
+
+	bits := integerBitSizes[def.Type]
+	max := new(big.Int).Lsh(big.NewInt(1), bits-1)
+	min := new(big.Int).Neg(max)
+	max.Sub(max, big.NewInt(1))
+
+	if n.Cmp(min) < 0 || n.Cmp(max) > 0 {
+		return nil, ErrOverflow
+	}
+
+	switch def.Type {
+	case TYPE_INT8:
+		return int8(n.Int64()), nil
+	case TYPE_INT16:
+		return int16(n.Int64()), nil
+	}
+
+	return int32(n.Int64()), nil
+}
+
+// getSizedUnsignedIntegerValue converts value to unsigned integer with specific bit size. Negative value is out of range.
+func getSizedUnsignedIntegerValue(def *Definition, data interface{}) (interface{}, error) {
+
+	n, err := getExactIntegerValue(def, data)
+	if err != nil {
+		return nil, err
+	}
+
+	bits := integerBitSizes[def.Type]
+	max := new(big.Int).Lsh(big.NewInt(1), bits)
+	max.Sub(max, big.NewInt(1))
+
+	if n.Sign() < 0 || n.Cmp(max) > 0 {
+		return nil, ErrOverflow
+	}
+
+	switch def.Type {
+	case TYPE_UINT8:
+		return uint8(n.Uint64()), nil
+	case TYPE_UINT16:
+		return uint16(n.Uint64()), nil
+	}
+
+	return uint32(n.Uint64()), nil
+}
+
+// getFloat32Value converts value to float32. Value which cannot be represented by the same digits in float32 loses precision.
+func getFloat32Value(def *Definition, data interface{}) (interface{}, error) {
+
+	switch d := data.(type) {
+	case int64:
+		f := float32(d)
+		if big.NewFloat(float64(f)).Cmp(new(big.Float).SetInt64(d)) != 0 {
+			return nil, ErrPrecisionLoss
+		}
+
+		return f, nil
+	case uint64:
+		f := float32(d)
+		if big.NewFloat(float64(f)).Cmp(new(big.Float).SetUint64(d)) != 0 {
+			return nil, ErrPrecisionLoss
+		}
+
+		return f, nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(d), 64)
+		if err != nil {
+			return nil, ErrUnparsable
+		}
+
+		return narrowFloat64(f)
+	}
+
+	f, err := getFloatValue(def, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return narrowFloat64(f)
+}
+
+func narrowFloat64(f float64) (float32, error) {
+
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return float32(f), nil
+	}
+
+	if math.Abs(f) > math.MaxFloat32 {
+		return 0, ErrOverflow
+	}
+
+	result := float32(f)
+	if strconv.FormatFloat(f, 'g', -1, 64) != strconv.FormatFloat(float64(result), 'g', -1, 32) {
+		return 0, ErrPrecisionLoss
+	}
+
+	return result, nil
+}
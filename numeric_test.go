@@ -0,0 +1,207 @@
+package schemer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testNumericSource = `{
+	"int8": { "type": "int8" },
+	"int16": { "type": "int16" },
+	"int32": { "type": "int32" },
+	"uint8": { "type": "uint8" },
+	"uint16": { "type": "uint16" },
+	"uint32": { "type": "uint32" },
+	"float32": { "type": "float32" }
+}`
+
+func TestNumericNormalize(t *testing.T) {
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(testNumericSource), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	result := schema.Normalize(map[string]interface{}{
+		"int8":    float64(-128),
+		"int16":   "32767",
+		"int32":   "1000",
+		"uint8":   int64(255),
+		"uint16":  uint64(65535),
+		"uint32":  true,
+		"float32": "0.1",
+	})
+
+	assert.Equal(t, int8(-128), result["int8"])
+	assert.Equal(t, int16(32767), result["int16"])
+	assert.Equal(t, int32(1000), result["int32"])
+	assert.Equal(t, uint8(255), result["uint8"])
+	assert.Equal(t, uint16(65535), result["uint16"])
+	assert.Equal(t, uint32(1), result["uint32"])
+	assert.Equal(t, float32(0.1), result["float32"])
+
+	// Overflow and precision loss
+	result = schema.Normalize(map[string]interface{}{
+		"int8":    float64(128),
+		"int16":   "1.5",
+		"int32":   "Brobridge",
+		"uint8":   int64(-1),
+		"uint16":  float64(65536),
+		"uint32":  "4294967296",
+		"float32": int64(16777217),
+	})
+
+	for name, v := range result {
+		assert.Nil(t, v, name)
+	}
+}
+
+func TestNumericNormalize_IntegerString(t *testing.T) {
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(`{
+	"int": { "type": "int" },
+	"int8": { "type": "int8" },
+	"int16": { "type": "int16" },
+	"int32": { "type": "int32" },
+	"uint8": { "type": "uint8" },
+	"uint32": { "type": "uint32" }
+}`), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// Fractions and exponents are not integers for all types
+	for _, source := range []string{"4/2", "1e3"} {
+		_, errs := schema.NormalizeWithErrors(map[string]interface{}{
+			"int":    source,
+			"int8":   source,
+			"int16":  source,
+			"int32":  source,
+			"uint8":  source,
+			"uint32": source,
+		})
+
+		assert.Len(t, errs, 6, source)
+		for _, e := range errs {
+			assert.Equal(t, ErrUnparsable, e.Err, e.Path)
+		}
+	}
+}
+
+func TestNumericValidate(t *testing.T) {
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(testNumericSource), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	violations := schema.Validate(map[string]interface{}{
+		"int8":    int64(127),
+		"int16":   float64(40000),
+		"int32":   "Brobridge",
+		"uint8":   int64(-1),
+		"uint16":  float64(1.5),
+		"uint32":  []interface{}{},
+		"float32": float64(0.123456789),
+	})
+	if !assert.Len(t, violations, 6) {
+		return
+	}
+
+	assert.Equal(t, "float32", violations[0].Path)
+	assert.Equal(t, VIOLATION_PRECISION, violations[0].Reason)
+	assert.Equal(t, "int16", violations[1].Path)
+	assert.Equal(t, VIOLATION_OUT_OF_RANGE, violations[1].Reason)
+	assert.Equal(t, "int32", violations[2].Path)
+	assert.Equal(t, VIOLATION_UNPARSABLE, violations[2].Reason)
+	assert.Equal(t, "uint16", violations[3].Path)
+	assert.Equal(t, VIOLATION_PRECISION, violations[3].Reason)
+	assert.Equal(t, "uint32", violations[4].Path)
+	assert.Equal(t, VIOLATION_INVALID_TYPE, violations[4].Reason)
+	assert.Equal(t, "uint8", violations[5].Path)
+	assert.Equal(t, VIOLATION_OUT_OF_RANGE, violations[5].Reason)
+}
+
+func TestNumericCompatibility(t *testing.T) {
+
+	assert.True(t, isReadable(TYPE_INT32, TYPE_INT16))
+	assert.True(t, isReadable(TYPE_INT32, TYPE_UINT16))
+	assert.True(t, isReadable(TYPE_INT64, TYPE_UINT32))
+	assert.True(t, isReadable(TYPE_UINT64, TYPE_UINT8))
+	assert.True(t, isReadable(TYPE_FLOAT64, TYPE_FLOAT32))
+	assert.True(t, isReadable(TYPE_FLOAT32, TYPE_INT16))
+
+	assert.False(t, isReadable(TYPE_INT32, TYPE_UINT32))
+	assert.False(t, isReadable(TYPE_UINT32, TYPE_INT8))
+	assert.False(t, isReadable(TYPE_INT16, TYPE_INT64))
+	assert.False(t, isReadable(TYPE_FLOAT32, TYPE_INT32))
+	assert.False(t, isReadable(TYPE_FLOAT32, TYPE_FLOAT64))
+}
+
+func TestNumericConstraint(t *testing.T) {
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(`{
+	"int8": { "type": "int8", "minimum": -10, "maximum": 10 },
+	"int16": { "type": "int16", "minimum": -10, "maximum": 10 },
+	"int32": { "type": "int32", "minimum": -10, "maximum": 10 },
+	"uint8": { "type": "uint8", "minimum": 1, "maximum": 10 },
+	"uint16": { "type": "uint16", "minimum": 1, "maximum": 10 },
+	"uint32": { "type": "uint32", "minimum": 1, "maximum": 10 },
+	"float32": { "type": "float32", "minimum": -1.1, "maximum": 1.1 }
+}`), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// Values within range
+	valid := map[string]interface{}{
+		"int8":    int64(-10),
+		"int16":   int64(10),
+		"int32":   int64(5),
+		"uint8":   int64(1),
+		"uint16":  int64(10),
+		"uint32":  int64(5),
+		"float32": float64(1.1),
+	}
+
+	result := schema.Normalize(valid)
+	assert.Equal(t, int8(-10), result["int8"])
+	assert.Equal(t, int16(10), result["int16"])
+	assert.Equal(t, int32(5), result["int32"])
+	assert.Equal(t, uint8(1), result["uint8"])
+	assert.Equal(t, uint16(10), result["uint16"])
+	assert.Equal(t, uint32(5), result["uint32"])
+	assert.Equal(t, float32(1.1), result["float32"])
+
+	assert.Len(t, schema.Validate(valid), 0)
+
+	// Values out of range
+	invalid := map[string]interface{}{
+		"int8":    int64(11),
+		"int16":   int64(-11),
+		"int32":   int64(100),
+		"uint8":   int64(0),
+		"uint16":  int64(11),
+		"uint32":  int64(100),
+		"float32": float64(1.2),
+	}
+
+	_, errs := schema.NormalizeWithErrors(invalid)
+	if assert.Len(t, errs, 7) {
+		for _, e := range errs {
+			assert.Equal(t, CONVERSION_CONSTRAINT, e.Reason, e.Path)
+		}
+	}
+
+	violations := schema.Validate(invalid)
+	if assert.Len(t, violations, 7) {
+		for _, v := range violations {
+			assert.Equal(t, VIOLATION_OUT_OF_RANGE, v.Reason, v.Path)
+		}
+	}
+}
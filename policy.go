@@ -0,0 +1,80 @@
+package schemer
+
+import (
+	"errors"
+)
+
+// ErrorPolicy decides what to do with a value which cannot be converted.
+// Without policy, the value returned by conversion is kept for fields and values set by path are dropped.
+type ErrorPolicy string
+
+const (
+	ERROR_POLICY_DROP     ErrorPolicy = "drop"
+	ERROR_POLICY_NULL     ErrorPolicy = "null"
+	ERROR_POLICY_DEFAULT  ErrorPolicy = "default"
+	ERROR_POLICY_KEEP_RAW ErrorPolicy = "keepRaw"
+	ERROR_POLICY_FAIL     ErrorPolicy = "fail"
+)
+
+var (
+	ErrInvalidErrorPolicy = errors.New("Invalid onError definition")
+)
+
+func parseErrorPolicy(v interface{}) (ErrorPolicy, error) {
+
+	name, _ := v.(string)
+	switch p := ErrorPolicy(name); p {
+	case ERROR_POLICY_DROP,
+		ERROR_POLICY_NULL,
+		ERROR_POLICY_DEFAULT,
+		ERROR_POLICY_KEEP_RAW,
+		ERROR_POLICY_FAIL:
+		return p, nil
+	}
+
+	return "", ErrInvalidErrorPolicy
+}
+
+// getErrorPolicy returns policy of definition, the default policy of schema is used if it is not specified
+func (s *Schema) getErrorPolicy(def *Definition) ErrorPolicy {
+
+	if len(def.OnError) > 0 {
+		return def.OnError
+	}
+
+	return s.OnError
+}
+
+// getFailure returns the first error which rejects the whole record
+func getFailure(errs []*ConversionError) error {
+
+	for _, e := range errs {
+		if e.Policy == ERROR_POLICY_FAIL {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// applyErrorPolicy returns value for failed conversion. False will be returned if field should be dropped.
+func applyErrorPolicy(policy ErrorPolicy, def *Definition, raw interface{}, converted interface{}) (interface{}, bool) {
+
+	switch policy {
+	case ERROR_POLICY_DROP, ERROR_POLICY_FAIL:
+		return nil, false
+	case ERROR_POLICY_NULL:
+		return nil, true
+	case ERROR_POLICY_DEFAULT:
+		if def.Default == nil {
+			return nil, true
+		}
+
+		return copyValue(def.Default), true
+	case ERROR_POLICY_KEEP_RAW:
+		return raw, true
+	}
+
+	// Keep converted value if no policy
+	return converted, true
+}
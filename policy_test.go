@@ -0,0 +1,220 @@
+package schemer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeWithErrorPolicy(t *testing.T) {
+
+	definition := `{
+	"$onError": "null",
+	"createdAt": { "type": "time" },
+	"count": { "type": "int", "onError": "drop" },
+	"level": { "type": "int8", "default": 1, "onError": "default" },
+	"code": { "type": "uint8", "onError": "keepRaw" },
+	"meta": {
+		"type": "map",
+		"fields": {
+			"size": { "type": "uint" }
+		}
+	}
+}`
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(definition), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, ERROR_POLICY_NULL, schema.OnError)
+	assert.Equal(t, ERROR_POLICY_DROP, schema.Fields["count"].OnError)
+
+	result, errs := schema.NormalizeWithErrors(map[string]interface{}{
+		"createdAt": "yesterday",
+		"count":     "many",
+		"level":     float64(200),
+		"code":      "A1",
+		"meta": map[string]interface{}{
+			"size": int64(-1),
+		},
+	})
+
+	assert.Len(t, errs, 5)
+	assert.Equal(t, map[string]interface{}{
+		"createdAt": nil,
+		"level":     int8(1),
+		"code":      "A1",
+		"meta": map[string]interface{}{
+			"size": nil,
+		},
+	}, result)
+
+	// Valid values are not affected
+	result = schema.Normalize(map[string]interface{}{
+		"createdAt": "2020-07-19T00:00:00Z",
+		"count":     "10",
+	})
+
+	assert.Equal(t, time.Date(2020, time.July, 19, 0, 0, 0, 0, time.UTC), result["createdAt"])
+	assert.Equal(t, int64(10), result["count"])
+
+	// Policy is kept
+	data, err := schema.MarshalJSON()
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	restored := NewSchema()
+	err = UnmarshalJSON(data, restored)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, ERROR_POLICY_NULL, restored.OnError)
+	assert.Equal(t, ERROR_POLICY_KEEP_RAW, restored.Fields["code"].OnError)
+
+	// Invalid policy
+	err = UnmarshalJSON([]byte(`{ "count": { "type": "int", "onError": "ignore" } }`), NewSchema())
+	assert.Equal(t, ErrInvalidErrorPolicy, err)
+
+	err = UnmarshalJSON([]byte(`{ "$onError": "ignore" }`), NewSchema())
+	assert.Equal(t, ErrInvalidErrorPolicy, err)
+}
+
+func TestNormalizeWithFailPolicy(t *testing.T) {
+
+	definition := `{
+	"$onError": "fail",
+	"createdAt": { "type": "time", "onError": "null" },
+	"count": { "type": "int" }
+}`
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(definition), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// Field policy overrides schema policy
+	result, errs := schema.NormalizeWithErrors(map[string]interface{}{
+		"createdAt": "yesterday",
+		"count":     "10",
+	})
+
+	assert.Len(t, errs, 1)
+	assert.Equal(t, map[string]interface{}{
+		"createdAt": nil,
+		"count":     int64(10),
+	}, result)
+
+	// The whole record is rejected
+	result, errs = schema.NormalizeWithErrors(map[string]interface{}{
+		"count": "many",
+	})
+
+	assert.Nil(t, result)
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, ERROR_POLICY_FAIL, errs[0].Policy)
+		assert.Equal(t, CONVERSION_UNPARSABLE, errs[0].Reason)
+	}
+}
+
+func TestNormalizeWithErrorPolicy_Union(t *testing.T) {
+
+	definition := `{
+	"$onError": "fail",
+	"payload": {
+		"type": "union",
+		"discriminator": "kind",
+		"types": {
+			"a": {
+				"type": "map",
+				"fields": {
+					"n": { "type": "int" },
+					"label": { "type": "string" }
+				}
+			}
+		}
+	}
+}`
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(definition), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// Errors inside alternative are reported with path
+	result, errs := schema.NormalizeWithErrors(map[string]interface{}{
+		"payload": map[string]interface{}{
+			"kind": "a",
+			"n":    "abc",
+		},
+	})
+
+	assert.Nil(t, result)
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, "payload.n", errs[0].Path)
+		assert.Equal(t, ERROR_POLICY_FAIL, errs[0].Policy)
+		assert.Equal(t, CONVERSION_UNPARSABLE, errs[0].Reason)
+	}
+
+	// Valid alternative
+	result, errs = schema.NormalizeWithErrors(map[string]interface{}{
+		"payload": map[string]interface{}{
+			"kind":  "a",
+			"n":     "10",
+			"label": "Brobridge",
+		},
+	})
+
+	assert.Nil(t, errs)
+	assert.Equal(t, map[string]interface{}{
+		"kind":  "a",
+		"n":     int64(10),
+		"label": "Brobridge",
+	}, result["payload"])
+}
+
+func TestNormalizeWithoutErrorPolicy(t *testing.T) {
+
+	definition := `{
+	"count": { "type": "int" },
+	"meta": {
+		"type": "map",
+		"fields": {
+			"count": { "type": "int" }
+		}
+	}
+}`
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(definition), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// Converted value is kept for field and value set by path is dropped
+	result, errs := schema.NormalizeWithErrors(map[string]interface{}{
+		"count":      "many",
+		"meta.count": "many",
+	})
+
+	assert.Len(t, errs, 2)
+	assert.Equal(t, int64(0), result["count"])
+	assert.NotContains(t, result, "meta.count")
+
+	// Policy is applied to path as well
+	schema.OnError = ERROR_POLICY_DROP
+	result, errs = schema.NormalizeWithErrors(map[string]interface{}{
+		"count":      "many",
+		"meta.count": "many",
+	})
+
+	assert.Len(t, errs, 2)
+	assert.NotContains(t, result, "count")
+	assert.NotContains(t, result, "meta.count")
+}
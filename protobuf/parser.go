@@ -0,0 +1,566 @@
+package protobuf
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnexpectedToken = errors.New("Unexpected token")
+	ErrUnexpectedEOF   = errors.New("Unexpected end of file")
+)
+
+type Field struct {
+	Name     string
+	JSONName string
+	Type     string
+	KeyType  string
+	Repeated bool
+	Optional bool
+	Map      bool
+	OneOf    string
+}
+
+type Message struct {
+	Name     string
+	FullName string
+	Fields   []*Field
+}
+
+type Enum struct {
+	Name     string
+	FullName string
+	Values   []string
+}
+
+type File struct {
+	Syntax   string
+	Package  string
+	Messages map[string]*Message
+	Enums    map[string]*Enum
+}
+
+type parser struct {
+	tokens []string
+	pos    int
+	file   *File
+}
+
+// Parse parses .proto source and collects message and enum declarations
+func Parse(source []byte) (*File, error) {
+
+	tokens, err := tokenize(string(source))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{
+		tokens: tokens,
+		file: &File{
+			Messages: make(map[string]*Message),
+			Enums:    make(map[string]*Enum),
+		},
+	}
+
+	err = p.parseFile()
+	if err != nil {
+		return nil, err
+	}
+
+	return p.file, nil
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || c == '.' || c == '-' || c == '+' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func tokenize(source string) ([]string, error) {
+
+	tokens := make([]string, 0)
+
+	for i := 0; i < len(source); {
+
+		c := source[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(source[i:], "//"):
+			// Line comment
+			end := strings.IndexByte(source[i:], '\n')
+			if end == -1 {
+				return tokens, nil
+			}
+
+			i += end
+		case strings.HasPrefix(source[i:], "/*"):
+			// Block comment
+			end := strings.Index(source[i+2:], "*/")
+			if end == -1 {
+				return nil, ErrUnexpectedEOF
+			}
+
+			i += end + 4
+		case c == '"' || c == '\'':
+			// String literal
+			j := i + 1
+			for j < len(source) && source[j] != c {
+				if source[j] == '\\' {
+					j++
+				}
+				j++
+			}
+
+			if j >= len(source) {
+				return nil, ErrUnexpectedEOF
+			}
+
+			tokens = append(tokens, source[i:j+1])
+			i = j + 1
+		case isIdentifierChar(c):
+			j := i
+			for j < len(source) && isIdentifierChar(source[j]) {
+				j++
+			}
+
+			tokens = append(tokens, source[i:j])
+			i = j
+		default:
+			tokens = append(tokens, string(c))
+			i++
+		}
+	}
+
+	return tokens, nil
+}
+
+func (p *parser) peek() string {
+
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() (string, error) {
+
+	if p.pos >= len(p.tokens) {
+		return "", ErrUnexpectedEOF
+	}
+
+	token := p.tokens[p.pos]
+	p.pos++
+
+	return token, nil
+}
+
+func (p *parser) expect(expected string) error {
+
+	token, err := p.next()
+	if err != nil {
+		return err
+	}
+
+	if token != expected {
+		return fmt.Errorf("%w: %s (expected %s)", ErrUnexpectedToken, token, expected)
+	}
+
+	return nil
+}
+
+// skipStatement skips tokens until the end of statement
+func (p *parser) skipStatement() error {
+
+	for {
+		token, err := p.next()
+		if err != nil {
+			return err
+		}
+
+		switch token {
+		case ";":
+			return nil
+		case "{":
+			return p.skipBlock()
+		}
+	}
+}
+
+// skipBlock skips tokens until the end of current block
+func (p *parser) skipBlock() error {
+
+	depth := 1
+	for depth > 0 {
+		token, err := p.next()
+		if err != nil {
+			return err
+		}
+
+		switch token {
+		case "{":
+			depth++
+		case "}":
+			depth--
+		}
+	}
+
+	return nil
+}
+
+func unquote(s string) string {
+
+	if len(s) >= 2 {
+		return s[1 : len(s)-1]
+	}
+
+	return s
+}
+
+func joinName(scope string, name string) string {
+
+	if len(scope) == 0 {
+		return name
+	}
+
+	return scope + "." + name
+}
+
+func (p *parser) parseFile() error {
+
+	for p.pos < len(p.tokens) {
+
+		token, _ := p.next()
+
+		switch token {
+		case "syntax":
+			if err := p.expect("="); err != nil {
+				return err
+			}
+
+			syntax, err := p.next()
+			if err != nil {
+				return err
+			}
+
+			p.file.Syntax = unquote(syntax)
+
+			if err := p.expect(";"); err != nil {
+				return err
+			}
+		case "package":
+			pkg, err := p.next()
+			if err != nil {
+				return err
+			}
+
+			p.file.Package = pkg
+
+			if err := p.expect(";"); err != nil {
+				return err
+			}
+		case "message":
+			if err := p.parseMessage(p.file.Package); err != nil {
+				return err
+			}
+		case "enum":
+			if err := p.parseEnum(p.file.Package); err != nil {
+				return err
+			}
+		case ";":
+			continue
+		default:
+			// import, option, service and extend are not related to message structure
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *parser) parseMessage(scope string) error {
+
+	name, err := p.next()
+	if err != nil {
+		return err
+	}
+
+	msg := &Message{
+		Name:     name,
+		FullName: joinName(scope, name),
+		Fields:   make([]*Field, 0),
+	}
+
+	p.file.Messages[msg.FullName] = msg
+
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+
+	return p.parseMessageBody(msg, "")
+}
+
+func (p *parser) parseMessageBody(msg *Message, oneOf string) error {
+
+	for {
+		token := p.peek()
+
+		switch token {
+		case "":
+			return ErrUnexpectedEOF
+		case "}":
+			p.pos++
+			return nil
+		case ";":
+			p.pos++
+		case "message":
+			p.pos++
+			if err := p.parseMessage(msg.FullName); err != nil {
+				return err
+			}
+		case "enum":
+			p.pos++
+			if err := p.parseEnum(msg.FullName); err != nil {
+				return err
+			}
+		case "oneof":
+			p.pos++
+
+			name, err := p.next()
+			if err != nil {
+				return err
+			}
+
+			if err := p.expect("{"); err != nil {
+				return err
+			}
+
+			if err := p.parseMessageBody(msg, name); err != nil {
+				return err
+			}
+		case "option", "reserved", "extensions", "extend":
+			p.pos++
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		default:
+			field, err := p.parseField()
+			if err != nil {
+				return err
+			}
+
+			field.OneOf = oneOf
+			msg.Fields = append(msg.Fields, field)
+		}
+	}
+}
+
+func (p *parser) parseField() (*Field, error) {
+
+	field := &Field{}
+
+	token, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case "repeated":
+		field.Repeated = true
+		token, err = p.next()
+	case "optional":
+		field.Optional = true
+		token, err = p.next()
+	case "required":
+		token, err = p.next()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if token == "map" {
+
+		field.Map = true
+
+		if err := p.expect("<"); err != nil {
+			return nil, err
+		}
+
+		if field.KeyType, err = p.next(); err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+
+		if token, err = p.next(); err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(">"); err != nil {
+			return nil, err
+		}
+	}
+
+	field.Type = token
+
+	if field.Name, err = p.next(); err != nil {
+		return nil, err
+	}
+
+	if err := p.expect("="); err != nil {
+		return nil, err
+	}
+
+	// Field number
+	if _, err := p.next(); err != nil {
+		return nil, err
+	}
+
+	// Field options
+	if p.peek() == "[" {
+		p.pos++
+		if err := p.parseFieldOptions(field); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.expect(";"); err != nil {
+		return nil, err
+	}
+
+	return field, nil
+}
+
+func (p *parser) parseFieldOptions(field *Field) error {
+
+	for {
+		if p.peek() == "]" {
+			p.pos++
+			return nil
+		}
+
+		if p.peek() == "," {
+			p.pos++
+			continue
+		}
+
+		name, err := p.parseOptionName()
+		if err != nil {
+			return err
+		}
+
+		if err := p.expect("="); err != nil {
+			return err
+		}
+
+		value, err := p.parseOptionValue()
+		if err != nil {
+			return err
+		}
+
+		// Unknown options are ignored
+		if name == "json_name" {
+			field.JSONName = unquote(value)
+		}
+	}
+}
+
+// parseOptionName parses option name which might be a custom option such as (validate.rules).string.min_len
+func (p *parser) parseOptionName() (string, error) {
+
+	var name strings.Builder
+
+	token, err := p.next()
+	if err != nil {
+		return "", err
+	}
+
+	if token == "(" {
+
+		extension, err := p.next()
+		if err != nil {
+			return "", err
+		}
+
+		if err := p.expect(")"); err != nil {
+			return "", err
+		}
+
+		name.WriteString("(" + extension + ")")
+	} else {
+		name.WriteString(token)
+	}
+
+	// Sub-fields of option
+	for strings.HasPrefix(p.peek(), ".") {
+		name.WriteString(p.peek())
+		p.pos++
+	}
+
+	return name.String(), nil
+}
+
+// parseOptionValue parses value of option, aggregate value in braces is skipped and returned as empty string
+func (p *parser) parseOptionValue() (string, error) {
+
+	token, err := p.next()
+	if err != nil {
+		return "", err
+	}
+
+	if token == "{" {
+		return "", p.skipBlock()
+	}
+
+	return token, nil
+}
+
+func (p *parser) parseEnum(scope string) error {
+
+	name, err := p.next()
+	if err != nil {
+		return err
+	}
+
+	enum := &Enum{
+		Name:     name,
+		FullName: joinName(scope, name),
+		Values:   make([]string, 0),
+	}
+
+	p.file.Enums[enum.FullName] = enum
+
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+
+	for {
+		token, err := p.next()
+		if err != nil {
+			return err
+		}
+
+		switch token {
+		case "}":
+			return nil
+		case ";":
+			continue
+		case "option", "reserved":
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		default:
+			enum.Values = append(enum.Values, token)
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		}
+	}
+}
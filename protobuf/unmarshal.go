@@ -0,0 +1,226 @@
+package protobuf
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/BrobridgeOrg/schemer"
+)
+
+var (
+	ErrMessageNotFound = errors.New("Message not found")
+	ErrUnknownType     = errors.New("Unknown type")
+	ErrRecursiveType   = errors.New("Recursive message is not supported")
+)
+
+var scalarTypes = map[string]string{
+	"double":   "float",
+	"float":    "float",
+	"int32":    "int",
+	"int64":    "int",
+	"sint32":   "int",
+	"sint64":   "int",
+	"sfixed32": "int",
+	"sfixed64": "int",
+	"uint32":   "uint",
+	"uint64":   "uint",
+	"fixed32":  "uint",
+	"fixed64":  "uint",
+	"bool":     "bool",
+	"string":   "string",
+	"bytes":    "binary",
+}
+
+var wellKnownTypes = map[string]string{
+	"google.protobuf.Timestamp":   "time",
+	"google.protobuf.Duration":    "duration",
+	"google.protobuf.DoubleValue": "float",
+	"google.protobuf.FloatValue":  "float",
+	"google.protobuf.Int64Value":  "int",
+	"google.protobuf.Int32Value":  "int",
+	"google.protobuf.UInt64Value": "uint",
+	"google.protobuf.UInt32Value": "uint",
+	"google.protobuf.BoolValue":   "bool",
+	"google.protobuf.StringValue": "string",
+	"google.protobuf.BytesValue":  "binary",
+	"google.protobuf.Struct":      "any",
+	"google.protobuf.Value":       "any",
+	"google.protobuf.ListValue":   "any",
+	"google.protobuf.Any":         "any",
+}
+
+type converter struct {
+	file    *File
+	pending map[string]bool
+}
+
+// Unmarshal parses .proto source and converts specific message to schema.
+// Fields are nullable because proto3 omits fields which have default values.
+func Unmarshal(source []byte, message string, s *schemer.Schema) error {
+
+	file, err := Parse(source)
+	if err != nil {
+		return err
+	}
+
+	return UnmarshalFile(file, message, s)
+}
+
+// UnmarshalFile converts specific message of parsed file to schema
+func UnmarshalFile(file *File, message string, s *schemer.Schema) error {
+
+	c := &converter{
+		file:    file,
+		pending: make(map[string]bool),
+	}
+
+	msg := c.resolveMessage(message)
+	if msg == nil {
+		return fmt.Errorf("%w: %s", ErrMessageNotFound, message)
+	}
+
+	fields, err := c.convertMessage(msg)
+	if err != nil {
+		return err
+	}
+
+	return schemer.Unmarshal(fields, s)
+}
+
+func (c *converter) resolveMessage(name string) *Message {
+
+	if msg, ok := c.file.Messages[name]; ok {
+		return msg
+	}
+
+	return c.file.Messages[joinName(c.file.Package, name)]
+}
+
+// resolveType looks up type name from the scope outward
+func (c *converter) resolveType(scope string, name string) (string, bool) {
+
+	if strings.HasPrefix(name, ".") {
+		name = name[1:]
+		_, isMessage := c.file.Messages[name]
+		_, isEnum := c.file.Enums[name]
+		return name, isMessage || isEnum
+	}
+
+	for {
+		fullName := joinName(scope, name)
+
+		_, isMessage := c.file.Messages[fullName]
+		_, isEnum := c.file.Enums[fullName]
+		if isMessage || isEnum {
+			return fullName, true
+		}
+
+		if len(scope) == 0 {
+			return name, false
+		}
+
+		idx := strings.LastIndex(scope, ".")
+		if idx == -1 {
+			scope = ""
+		} else {
+			scope = scope[:idx]
+		}
+	}
+}
+
+func (c *converter) convertMessage(msg *Message) (map[string]interface{}, error) {
+
+	c.pending[msg.FullName] = true
+	defer delete(c.pending, msg.FullName)
+
+	fields := make(map[string]interface{}, len(msg.Fields))
+	for _, field := range msg.Fields {
+
+		def, err := c.convertField(msg, field)
+		if err != nil {
+			return nil, err
+		}
+
+		name := field.Name
+		if len(field.JSONName) > 0 {
+			name = field.JSONName
+		}
+
+		fields[name] = def
+	}
+
+	return fields, nil
+}
+
+func (c *converter) convertField(msg *Message, field *Field) (map[string]interface{}, error) {
+
+	// Keys of map are dynamic
+	if field.Map {
+		return map[string]interface{}{
+			"type": "any",
+		}, nil
+	}
+
+	def, err := c.convertType(msg, field.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if field.Repeated {
+		return map[string]interface{}{
+			"type":    "array",
+			"subtype": def,
+		}, nil
+	}
+
+	return def, nil
+}
+
+func (c *converter) convertType(msg *Message, t string) (map[string]interface{}, error) {
+
+	if name, ok := scalarTypes[t]; ok {
+		return map[string]interface{}{
+			"type": name,
+		}, nil
+	}
+
+	if name, ok := wellKnownTypes[strings.TrimPrefix(t, ".")]; ok {
+		return map[string]interface{}{
+			"type": name,
+		}, nil
+	}
+
+	fullName, ok := c.resolveType(msg.FullName, t)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s (%s)", ErrUnknownType, t, msg.FullName)
+	}
+
+	// Enum values are represented by names
+	if enum, ok := c.file.Enums[fullName]; ok {
+
+		symbols := make([]interface{}, len(enum.Values))
+		for i, v := range enum.Values {
+			symbols[i] = v
+		}
+
+		return map[string]interface{}{
+			"type":    "enum",
+			"symbols": symbols,
+		}, nil
+	}
+
+	if c.pending[fullName] {
+		return nil, fmt.Errorf("%w: %s", ErrRecursiveType, fullName)
+	}
+
+	fields, err := c.convertMessage(c.file.Messages[fullName])
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"type":   "map",
+		"fields": fields,
+	}, nil
+}
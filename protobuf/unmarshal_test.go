@@ -0,0 +1,156 @@
+package protobuf
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/BrobridgeOrg/schemer"
+	"github.com/BrobridgeOrg/schemer/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var testProto = `
+syntax = "proto3";
+
+package brobridge.events;
+
+import "google/protobuf/timestamp.proto";
+
+option go_package = "github.com/BrobridgeOrg/events";
+
+/*
+ * Account events
+ */
+enum Status {
+	STATUS_UNKNOWN = 0;
+	STATUS_ACTIVE = 1;
+	STATUS_INACTIVE = 2 [deprecated = true];
+}
+
+message Address {
+	string city = 1;
+	string zip_code = 2 [json_name = "zipCode"];
+}
+
+message AccountCreated {
+	message Profile {
+		string title = 1;
+		Address address = 2;
+	}
+
+	reserved 20, 21;
+
+	uint64 id = 1;
+	string name = 2; // Full name
+	int32 age = 3;
+	sint64 balance = 4;
+	double score = 5;
+	bool enabled = 6;
+	bytes avatar = 7;
+	Status status = 8;
+	google.protobuf.Timestamp created_at = 9;
+	google.protobuf.Duration timeout = 17;
+	repeated string tags = 10;
+	repeated Profile profiles = 11;
+	map<string, string> labels = 12;
+	optional fixed32 flags = 13;
+	.brobridge.events.Address address = 14;
+
+	oneof contact {
+		string email = 15;
+		string phone = 16;
+	}
+}
+
+service AccountService {
+	rpc Create(AccountCreated) returns (AccountCreated) {}
+}
+`
+
+func TestUnmarshal(t *testing.T) {
+
+	schema := schemer.NewSchema()
+	err := Unmarshal([]byte(testProto), "AccountCreated", schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, schemer.TYPE_UINT64, schema.Fields["id"].Type)
+	assert.Equal(t, schemer.TYPE_STRING, schema.Fields["name"].Type)
+	assert.Equal(t, schemer.TYPE_INT64, schema.Fields["age"].Type)
+	assert.Equal(t, schemer.TYPE_INT64, schema.Fields["balance"].Type)
+	assert.Equal(t, schemer.TYPE_FLOAT64, schema.Fields["score"].Type)
+	assert.Equal(t, schemer.TYPE_BOOLEAN, schema.Fields["enabled"].Type)
+	assert.Equal(t, schemer.TYPE_BINARY, schema.Fields["avatar"].Type)
+	assert.Equal(t, schemer.TYPE_ENUM, schema.Fields["status"].Type)
+	assert.Equal(t, []string{"STATUS_UNKNOWN", "STATUS_ACTIVE", "STATUS_INACTIVE"}, schema.Fields["status"].Info.(*types.Enum).Symbols)
+	assert.Equal(t, schemer.TYPE_TIME, schema.Fields["created_at"].Type)
+	assert.Equal(t, schemer.TYPE_DURATION, schema.Fields["timeout"].Type)
+	assert.Equal(t, schemer.TYPE_ARRAY, schema.Fields["tags"].Type)
+	assert.Equal(t, schemer.TYPE_STRING, schema.Fields["tags"].Subtype.Type)
+	assert.Equal(t, schemer.TYPE_ANY, schema.Fields["labels"].Type)
+	assert.Equal(t, schemer.TYPE_UINT64, schema.Fields["flags"].Type)
+	assert.Equal(t, schemer.TYPE_STRING, schema.Fields["email"].Type)
+	assert.Equal(t, schemer.TYPE_STRING, schema.Fields["phone"].Type)
+
+	profiles := schema.Fields["profiles"]
+	assert.Equal(t, schemer.TYPE_ARRAY, profiles.Type)
+	assert.Equal(t, schemer.TYPE_MAP, profiles.Subtype.Type)
+	assert.Equal(t, schemer.TYPE_STRING, profiles.Subtype.Schema.Fields["title"].Type)
+
+	address := profiles.Subtype.Schema.Fields["address"]
+	assert.Equal(t, schemer.TYPE_MAP, address.Type)
+	assert.Equal(t, schemer.TYPE_STRING, address.Schema.Fields["zipCode"].Type)
+
+	assert.Equal(t, schemer.TYPE_MAP, schema.Fields["address"].Type)
+
+	// Nested message can be converted by full name
+	schema = schemer.NewSchema()
+	err = Unmarshal([]byte(testProto), "brobridge.events.AccountCreated.Profile", schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, schemer.TYPE_STRING, schema.Fields["title"].Type)
+}
+
+func TestUnmarshal_Errors(t *testing.T) {
+
+	testCases := map[string]error{
+		`message A { B b = 1; }`:           ErrUnknownType,
+		`message A { repeated A a = 1; }`:  ErrRecursiveType,
+		`message B { string b = 1; }`:      ErrMessageNotFound,
+		`message A { string a = 1 }`:       ErrUnexpectedToken,
+		`message A { string a = 1;`:        ErrUnexpectedEOF,
+		`message A { /* string a = 1; }`:   ErrUnexpectedEOF,
+		`message A { string a = 1; } "abc`: ErrUnexpectedEOF,
+	}
+
+	for source, expected := range testCases {
+		err := Unmarshal([]byte(source), "A", schemer.NewSchema())
+		assert.True(t, errors.Is(err, expected), source)
+	}
+}
+
+func TestUnmarshal_CustomOptions(t *testing.T) {
+
+	source := `
+syntax = "proto3";
+
+message A {
+	string name = 1 [(validate.rules).string.min_len = 1, json_name = "fullName"];
+	string email = 2 [(validate.rules).string = { email: true, max_len: 100 }];
+	int32 age = 3 [deprecated = true, (.custom.field) = "age"];
+}
+`
+
+	schema := schemer.NewSchema()
+	err := Unmarshal([]byte(source), "A", schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, schemer.TYPE_STRING, schema.Fields["fullName"].Type)
+	assert.Equal(t, schemer.TYPE_STRING, schema.Fields["email"].Type)
+	assert.Equal(t, schemer.TYPE_INT64, schema.Fields["age"].Type)
+}
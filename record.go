@@ -13,26 +13,46 @@ func NewRecord(schema *Schema, raw map[string]interface{}) *Record {
 }
 
 func (r *Record) GetValue(valuePath string) *Value {
+	value, _ := r.GetValueWithError(valuePath)
+	return value
+}
+
+// GetValueWithError returns value at specific path. ConversionError will be returned if value cannot be converted.
+func (r *Record) GetValueWithError(valuePath string) (*Value, error) {
 
 	parts := r.schema.parsePath(valuePath)
 
-	def := r.schema.getDefinition(parts)
+	def := r.schema.resolveDefinition(parts, r.raw)
 	if def == nil {
-		return nil
+		return nil, nil
+	}
+
+	data := r.getValue(parts)
+
+	// Select alternative of union
+	resolved := def.Resolve(data)
+	if resolved == nil {
+		return nil, NewConversionError(valuePath, def, data, ErrInvalidType)
 	}
 
+	def = resolved
+
 	// Create a new value from raw data
 	value := NewValue(def)
 
 	// get value with defintion type from raw data
-	v, err := getValue(def, r.getValue(parts))
+	v, err := getValue(def, data)
 	if err != nil {
-		return nil
+		if data == nil {
+			return nil, nil
+		}
+
+		return nil, NewConversionError(valuePath, def, data, err)
 	}
 
 	value.Data = v
 
-	return value
+	return value, nil
 }
 
 func (r *Record) getValue(parts []string) interface{} {
@@ -0,0 +1,221 @@
+package schemer
+
+import (
+	"strings"
+)
+
+// Prefix of reference to named definition in $defs
+const refPrefix = "#/$defs/"
+
+func parseRefName(v interface{}) (string, bool) {
+
+	s, ok := v.(string)
+	if !ok {
+		return "", false
+	}
+
+	name := strings.TrimPrefix(s, refPrefix)
+	if len(name) == 0 || strings.Contains(name, "/") {
+		return "", false
+	}
+
+	return name, true
+}
+
+// extractRefRawDefinition parses reference to named definition. Only notNull can be used with $ref.
+func extractRefRawDefinition(v map[string]interface{}) (*RawDefinition, error) {
+
+	name, ok := parseRefName(v["$ref"])
+	if !ok {
+		return nil, ErrInvalidRefDefinition
+	}
+
+	raw := NewRawDefinition()
+	raw.Ref = name
+
+	for key, value := range v {
+		switch key {
+		case "$ref":
+			continue
+		case "notNull":
+			notNull, ok := value.(bool)
+			if !ok {
+				return nil, ErrInvalidNotNullDefinition
+			}
+
+			raw.NotNull = notNull
+		default:
+			return nil, ErrInvalidRefDefinition
+		}
+	}
+
+	return raw, nil
+}
+
+func unmarshalDefs(data interface{}) (map[string]*Definition, error) {
+
+	m, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, ErrInvalidDefsDefinition
+	}
+
+	defs := make(map[string]*Definition, len(m))
+	for name, value := range m {
+
+		if len(name) == 0 || strings.Contains(name, "/") {
+			return nil, ErrInvalidDefsDefinition
+		}
+
+		raw, err := extractRawDefinition(value)
+		if err != nil {
+			return nil, err
+		}
+
+		// Alias is not supported
+		if len(raw.Ref) > 0 {
+			return nil, ErrInvalidDefsDefinition
+		}
+
+		def, err := createDefinitionFromRawDefinition(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		def.Ref = name
+		defs[name] = def
+	}
+
+	return defs, nil
+}
+
+// refResolver replaces references with shared definitions of $defs
+type refResolver struct {
+	defs      map[string]*Definition
+	overrides []func()
+}
+
+func resolveRefs(s *Schema, defs map[string]*Definition) error {
+
+	r := &refResolver{
+		defs: defs,
+	}
+
+	for _, def := range defs {
+		err := r.resolveChildren(def)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := r.resolveSchema(s)
+	if err != nil {
+		return err
+	}
+
+	// Shared definitions are complete now, so copies with different notNull can be created
+	for _, override := range r.overrides {
+		override()
+	}
+
+	return nil
+}
+
+func (r *refResolver) resolveSchema(s *Schema) error {
+
+	if s == nil {
+		return nil
+	}
+
+	for key, def := range s.Fields {
+
+		name := key
+		err := r.resolveRef(def, func(d *Definition) {
+			s.Fields[name] = d
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveRef replaces reference by set function, or resolves children if it is not a reference
+func (r *refResolver) resolveRef(def *Definition, set func(*Definition)) error {
+
+	if def == nil {
+		return nil
+	}
+
+	if len(def.Ref) == 0 {
+		return r.resolveChildren(def)
+	}
+
+	shared, ok := r.defs[def.Ref]
+	if !ok {
+		return ErrInvalidRefDefinition
+	}
+
+	// Resolved already
+	if shared == def {
+		return nil
+	}
+
+	set(shared)
+
+	// Nullability belongs to the field which refers to shared definition
+	if def.NotNull != shared.NotNull {
+		notNull := def.NotNull
+		r.overrides = append(r.overrides, func() {
+			d := *shared
+			d.NotNull = notNull
+			set(&d)
+		})
+	}
+
+	return nil
+}
+
+func (r *refResolver) resolveChildren(def *Definition) error {
+
+	err := r.resolveSchema(def.Schema)
+	if err != nil {
+		return err
+	}
+
+	err = r.resolveRef(def.Subtype, func(d *Definition) {
+		def.Subtype = d
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, t := range def.Types {
+
+		idx := i
+		err := r.resolveRef(t, func(d *Definition) {
+			def.Types[idx] = d
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for key, t := range def.Mapping {
+
+		name := key
+		err := r.resolveRef(t, func(d *Definition) {
+			def.Mapping[name] = d
+		})
+		if err != nil {
+			return err
+		}
+
+		// Discriminator is a field of map
+		if def.Mapping[name].Type != TYPE_MAP {
+			return ErrInvalidUnionDefinition
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package schemer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testRefsSource = `{
+	"$defs": {
+		"address": {
+			"type": "map",
+			"fields": {
+				"city": { "type": "string" },
+				"zip": { "type": "int" }
+			}
+		},
+		"node": {
+			"type": "map",
+			"fields": {
+				"name": { "type": "string" },
+				"children": { "type": "array", "subtype": { "$ref": "#/$defs/node" } }
+			}
+		}
+	},
+	"home": { "$ref": "#/$defs/address", "notNull": true },
+	"office": { "$ref": "#/$defs/address" },
+	"tree": { "$ref": "#/$defs/node" }
+}`
+
+func TestRefsNormalize(t *testing.T) {
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(testRefsSource), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// Definitions are shared
+	node := schema.Defs["node"]
+	assert.Equal(t, node, schema.Fields["tree"])
+	assert.Equal(t, node, node.Schema.Fields["children"].Subtype)
+	assert.Equal(t, schema.Fields["home"].Schema, schema.Fields["office"].Schema)
+	assert.True(t, schema.Fields["home"].NotNull)
+	assert.False(t, schema.Fields["office"].NotNull)
+
+	result := schema.Normalize(map[string]interface{}{
+		"home": map[string]interface{}{
+			"city": "Taipei",
+			"zip":  "100",
+		},
+		"tree": map[string]interface{}{
+			"name": "root",
+			"children": []interface{}{
+				map[string]interface{}{
+					"name": "leaf",
+					"children": []interface{}{
+						map[string]interface{}{
+							"name": "1",
+						},
+					},
+				},
+			},
+		},
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"city": "Taipei",
+		"zip":  int64(100),
+	}, result["home"])
+
+	assert.Equal(t, map[string]interface{}{
+		"name": "root",
+		"children": []interface{}{
+			map[string]interface{}{
+				"name": "leaf",
+				"children": []interface{}{
+					map[string]interface{}{
+						"name": "1",
+					},
+				},
+			},
+		},
+	}, result["tree"])
+
+	def := schema.GetDefinition("tree.children[0].children[1].name")
+	if assert.NotNil(t, def) {
+		assert.Equal(t, TYPE_STRING, def.Type)
+	}
+
+	violations := schema.Validate(map[string]interface{}{
+		"tree": map[string]interface{}{
+			"children": []interface{}{
+				map[string]interface{}{
+					"children": "none",
+				},
+			},
+		},
+	})
+	if assert.Len(t, violations, 2) {
+		assert.Equal(t, "home", violations[0].Path)
+		assert.Equal(t, VIOLATION_NOT_NULL, violations[0].Reason)
+		assert.Equal(t, "tree.children[0].children", violations[1].Path)
+		assert.Equal(t, VIOLATION_INVALID_TYPE, violations[1].Reason)
+	}
+}
+
+func TestRefsMarshalJSON(t *testing.T) {
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(testRefsSource), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	data, err := schema.MarshalJSON()
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	restored := NewSchema()
+	err = UnmarshalJSON(data, restored)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Nil(t, SchemaDiff(schema, restored))
+	assert.True(t, IsCompatible(schema, restored, COMPATIBILITY_FULL))
+	assert.Equal(t, restored.Defs["node"], restored.Fields["tree"])
+
+	// Changes of shared definition
+	changed := NewSchema()
+	err = UnmarshalJSON([]byte(`{
+	"$defs": {
+		"node": {
+			"type": "map",
+			"fields": {
+				"name": { "type": "int" },
+				"children": { "type": "array", "subtype": { "$ref": "#/$defs/node" } }
+			}
+		}
+	},
+	"home": { "type": "string" },
+	"tree": { "$ref": "#/$defs/node" }
+}`), changed)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	diffs := SchemaDiff(schema, changed)
+	if assert.Len(t, diffs, 4) {
+		assert.Equal(t, "home", diffs[0].Path)
+		assert.Equal(t, "$ref", diffs[0].Changes[0].Property)
+		assert.Equal(t, "office", diffs[1].Path)
+		assert.Equal(t, DIFF_REMOVED, diffs[1].Action)
+		assert.Equal(t, "$defs.address", diffs[2].Path)
+		assert.Equal(t, DIFF_REMOVED, diffs[2].Action)
+		assert.Equal(t, "$defs.node", diffs[3].Path)
+		assert.Equal(t, "$defs.node.name", diffs[3].Fields[0].Path)
+	}
+
+	issues := CheckCompatibility(schema, changed, COMPATIBILITY_BACKWARD)
+	if assert.Len(t, issues, 2) {
+		paths := []string{issues[0].Path, issues[1].Path}
+		assert.Contains(t, paths, "home")
+		assert.Contains(t, paths, "tree.name")
+	}
+}
+
+func TestRefsInvalidDefinition(t *testing.T) {
+
+	// Unknown reference
+	err := UnmarshalJSON([]byte(`{ "home": { "$ref": "#/$defs/address" } }`), NewSchema())
+	assert.Equal(t, ErrInvalidRefDefinition, err)
+
+	// Reference cannot be combined with other keys
+	err = UnmarshalJSON([]byte(`{
+	"$defs": { "address": { "type": "string" } },
+	"home": { "$ref": "#/$defs/address", "type": "string" }
+}`), NewSchema())
+	assert.Equal(t, ErrInvalidRefDefinition, err)
+
+	// Alias
+	err = UnmarshalJSON([]byte(`{
+	"$defs": { "address": { "type": "string" }, "location": { "$ref": "#/$defs/address" } }
+}`), NewSchema())
+	assert.Equal(t, ErrInvalidDefsDefinition, err)
+
+	// Discriminated union requires map
+	err = UnmarshalJSON([]byte(`{
+	"$defs": { "address": { "type": "string" } },
+	"event": { "type": "union", "discriminator": "kind", "types": { "a": { "$ref": "#/$defs/address" } } }
+}`), NewSchema())
+	assert.Equal(t, ErrInvalidUnionDefinition, err)
+
+	// Named definitions are not available for single definition
+	var def Definition
+	err = UnmarshalDefinition(map[string]interface{}{
+		"$ref": "#/$defs/address",
+	}, &def)
+	assert.Equal(t, ErrInvalidRefDefinition, err)
+}
@@ -0,0 +1,266 @@
+package registry
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/BrobridgeOrg/schemer"
+)
+
+const LatestVersion = -1
+
+var (
+	ErrSubjectNotFound = errors.New("Subject not found")
+	ErrVersionNotFound = errors.New("Version not found")
+	ErrSchemaNotFound  = errors.New("Schema not found")
+	ErrInvalidSubject  = errors.New("Invalid subject")
+)
+
+// IncompatibleError is returned when a new schema violates compatibility mode of registry
+type IncompatibleError struct {
+	Subject string
+	Issues  []*schemer.CompatibilityIssue
+}
+
+func (e *IncompatibleError) Error() string {
+	return fmt.Sprintf("Incompatible schema for subject %s: %d issue(s)", e.Subject, len(e.Issues))
+}
+
+type Entry struct {
+	ID      int64
+	Subject string
+	Version int
+	Schema  *schemer.Schema
+}
+
+type RegistryOpt func(*Registry)
+
+func WithStorage(storage Storage) func(*Registry) {
+	return func(r *Registry) {
+		r.storage = storage
+	}
+}
+
+func WithCompatibility(mode schemer.CompatibilityMode) func(*Registry) {
+	return func(r *Registry) {
+		r.compatibility = mode
+	}
+}
+
+type Registry struct {
+	mutex         sync.RWMutex
+	storage       Storage
+	compatibility schemer.CompatibilityMode
+	subjects      map[string][]*Entry
+	ids           map[int64]*Entry
+	lastID        int64
+}
+
+// NewRegistry creates a registry and loads schemas from storage
+func NewRegistry(opts ...RegistryOpt) (*Registry, error) {
+
+	r := &Registry{
+		storage:       nil,
+		compatibility: schemer.COMPATIBILITY_BACKWARD,
+		subjects:      make(map[string][]*Entry),
+		ids:           make(map[int64]*Entry),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.storage == nil {
+		return r, nil
+	}
+
+	entries, err := r.storage.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		r.add(entry)
+	}
+
+	// Versions should be in order
+	for _, versions := range r.subjects {
+		sort.Slice(versions, func(i, j int) bool {
+			return versions[i].Version < versions[j].Version
+		})
+	}
+
+	return r, nil
+}
+
+func (r *Registry) add(entry *Entry) {
+
+	r.subjects[entry.Subject] = append(r.subjects[entry.Subject], entry)
+	r.ids[entry.ID] = entry
+
+	if entry.ID > r.lastID {
+		r.lastID = entry.ID
+	}
+}
+
+// isSameSchema compares serialized schemas, so every setting and definition detail is taken into account
+func isSameSchema(a *schemer.Schema, b *schemer.Schema) bool {
+
+	oldData, err := a.MarshalJSON()
+	if err != nil {
+		return false
+	}
+
+	newData, err := b.MarshalJSON()
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(oldData, newData)
+}
+
+// isValidSubject checks subject name which cannot be empty or special names of directory
+func isValidSubject(subject string) bool {
+	return subject != "" && subject != "." && subject != ".."
+}
+
+func (r *Registry) GetCompatibility() schemer.CompatibilityMode {
+	return r.compatibility
+}
+
+// Register stores schema as a new version of subject. Existing entry will be returned if schema is the same as the latest version.
+func (r *Registry) Register(subject string, s *schemer.Schema) (*Entry, error) {
+
+	if !isValidSubject(subject) {
+		return nil, ErrInvalidSubject
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	versions := r.subjects[subject]
+	if len(versions) > 0 {
+
+		latest := versions[len(versions)-1]
+
+		// Nothing changed
+		if isSameSchema(latest.Schema, s) {
+			return latest, nil
+		}
+
+		issues := schemer.CheckCompatibility(latest.Schema, s, r.compatibility)
+		if len(issues) > 0 {
+			return nil, &IncompatibleError{
+				Subject: subject,
+				Issues:  issues,
+			}
+		}
+	}
+
+	entry := &Entry{
+		ID:      r.lastID + 1,
+		Subject: subject,
+		Version: len(versions) + 1,
+		Schema:  s,
+	}
+
+	if r.storage != nil {
+		err := r.storage.Save(entry)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	r.add(entry)
+
+	return entry, nil
+}
+
+// Subjects returns names of all subjects in order
+func (r *Registry) Subjects() []string {
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	subjects := make([]string, 0, len(r.subjects))
+	for subject := range r.subjects {
+		subjects = append(subjects, subject)
+	}
+
+	sort.Strings(subjects)
+
+	return subjects
+}
+
+// Versions returns all versions of subject
+func (r *Registry) Versions(subject string) ([]int, error) {
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	entries, ok := r.subjects[subject]
+	if !ok {
+		return nil, ErrSubjectNotFound
+	}
+
+	versions := make([]int, len(entries))
+	for i, entry := range entries {
+		versions[i] = entry.Version
+	}
+
+	return versions, nil
+}
+
+// GetEntry returns specific version of subject. LatestVersion can be used to get the latest one.
+func (r *Registry) GetEntry(subject string, version int) (*Entry, error) {
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	entries, ok := r.subjects[subject]
+	if !ok || len(entries) == 0 {
+		return nil, ErrSubjectNotFound
+	}
+
+	if version == LatestVersion {
+		return entries[len(entries)-1], nil
+	}
+
+	for _, entry := range entries {
+		if entry.Version == version {
+			return entry, nil
+		}
+	}
+
+	return nil, ErrVersionNotFound
+}
+
+func (r *Registry) GetSchema(subject string, version int) (*schemer.Schema, error) {
+
+	entry, err := r.GetEntry(subject, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry.Schema, nil
+}
+
+func (r *Registry) GetLatestSchema(subject string) (*schemer.Schema, error) {
+	return r.GetSchema(subject, LatestVersion)
+}
+
+func (r *Registry) GetSchemaByID(id int64) (*schemer.Schema, error) {
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	entry, ok := r.ids[id]
+	if !ok {
+		return nil, ErrSchemaNotFound
+	}
+
+	return entry.Schema, nil
+}
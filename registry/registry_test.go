@@ -0,0 +1,226 @@
+package registry
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BrobridgeOrg/schemer"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSchema(t *testing.T, source string) *schemer.Schema {
+
+	s := schemer.NewSchema()
+	err := schemer.UnmarshalJSON([]byte(source), s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+func TestRegistry(t *testing.T) {
+
+	dir := t.TempDir()
+
+	r, err := NewRegistry(
+		WithStorage(NewFileStorage(dir)),
+		WithCompatibility(schemer.COMPATIBILITY_BACKWARD),
+	)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// Version 1
+	entry, err := r.Register("accounts/created", newSchema(t, `{
+	"id": { "type": "int", "notNull": true },
+	"name": { "type": "string" }
+}`))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, int64(1), entry.ID)
+	assert.Equal(t, 1, entry.Version)
+
+	// Same schema should not create a new version
+	entry, err = r.Register("accounts/created", newSchema(t, `{
+	"id": { "type": "int", "notNull": true },
+	"name": { "type": "string" }
+}`))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, entry.Version)
+
+	// Version 2
+	entry, err = r.Register("accounts/created", newSchema(t, `{
+	"id": { "type": "int", "notNull": true },
+	"name": { "type": "string" },
+	"createdAt": { "type": "time", "precision": "microsecond" }
+}`))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, int64(2), entry.ID)
+	assert.Equal(t, 2, entry.Version)
+
+	// Incompatible schema
+	_, err = r.Register("accounts/created", newSchema(t, `{
+	"id": { "type": "bool", "notNull": true },
+	"level": { "type": "int", "notNull": true }
+}`))
+
+	var incompatibleErr *IncompatibleError
+	if assert.True(t, errors.As(err, &incompatibleErr)) {
+		assert.Len(t, incompatibleErr.Issues, 2)
+	}
+
+	// Another subject
+	entry, err = r.Register("orders", newSchema(t, `{
+	"id": { "type": "int" }
+}`))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), entry.ID)
+	assert.Equal(t, 1, entry.Version)
+
+	// Reload from file system
+	r, err = NewRegistry(WithStorage(NewFileStorage(dir)))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, []string{"accounts/created", "orders"}, r.Subjects())
+
+	versions, err := r.Versions("accounts/created")
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2}, versions)
+
+	s, err := r.GetLatestSchema("accounts/created")
+	if assert.Nil(t, err) {
+		assert.Equal(t, schemer.TYPE_TIME, s.Fields["createdAt"].Type)
+	}
+
+	s, err = r.GetSchema("accounts/created", 1)
+	if assert.Nil(t, err) {
+		assert.NotContains(t, s.Fields, "createdAt")
+		assert.True(t, s.Fields["id"].NotNull)
+	}
+
+	s, err = r.GetSchemaByID(3)
+	if assert.Nil(t, err) {
+		assert.Equal(t, schemer.TYPE_INT64, s.Fields["id"].Type)
+	}
+
+	// New ID should follow loaded entries
+	entry, err = r.Register("orders", newSchema(t, `{
+	"id": { "type": "int" },
+	"amount": { "type": "float" }
+}`))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), entry.ID)
+	assert.Equal(t, 2, entry.Version)
+
+	_, err = r.GetSchema("unknown", LatestVersion)
+	assert.Equal(t, ErrSubjectNotFound, err)
+
+	_, err = r.GetSchema("orders", 9)
+	assert.Equal(t, ErrVersionNotFound, err)
+
+	_, err = r.GetSchemaByID(99)
+	assert.Equal(t, ErrSchemaNotFound, err)
+}
+
+func TestRegistry_WithoutStorage(t *testing.T) {
+
+	r, err := NewRegistry(WithCompatibility(schemer.COMPATIBILITY_NONE))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	_, err = r.Register("events", newSchema(t, `{ "id": { "type": "int" } }`))
+	assert.Nil(t, err)
+
+	// Any change is allowed
+	entry, err := r.Register("events", newSchema(t, `{ "id": { "type": "string" } }`))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, entry.Version)
+	assert.Equal(t, schemer.COMPATIBILITY_NONE, r.GetCompatibility())
+}
+
+func TestRegistry_InvalidSubject(t *testing.T) {
+
+	root := t.TempDir()
+	dir := filepath.Join(root, "schemas")
+
+	r, err := NewRegistry(WithStorage(NewFileStorage(dir)))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	for _, subject := range []string{"", ".", ".."} {
+		_, err = r.Register(subject, newSchema(t, `{ "id": { "type": "int" } }`))
+		assert.True(t, errors.Is(err, ErrInvalidSubject), subject)
+	}
+
+	// Storage rejects subjects which point to outside of directory as well
+	err = NewFileStorage(dir).Save(&Entry{
+		ID:      1,
+		Subject: "..",
+		Version: 1,
+		Schema:  newSchema(t, `{ "id": { "type": "int" } }`),
+	})
+	assert.True(t, errors.Is(err, ErrInvalidSubject))
+
+	files, err := os.ReadDir(root)
+	if assert.Nil(t, err) {
+		assert.Len(t, files, 0)
+	}
+
+	// Subject with separator is kept in storage directory
+	_, err = r.Register("../accounts", newSchema(t, `{ "id": { "type": "int" } }`))
+	assert.Nil(t, err)
+
+	files, err = os.ReadDir(root)
+	if assert.Nil(t, err) && assert.Len(t, files, 1) {
+		assert.Equal(t, "schemas", files[0].Name())
+	}
+}
+
+func TestRegistry_ChangedDetails(t *testing.T) {
+
+	r, err := NewRegistry(WithCompatibility(schemer.COMPATIBILITY_NONE))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	_, err = r.Register("orders", newSchema(t, `{ "price": { "type": "decimal", "precision": 10, "scale": 2 } }`))
+	assert.Nil(t, err)
+
+	// Same schema
+	entry, err := r.Register("orders", newSchema(t, `{ "price": { "type": "decimal", "precision": 10, "scale": 2 } }`))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, entry.Version)
+
+	// Precision of decimal
+	entry, err = r.Register("orders", newSchema(t, `{ "price": { "type": "decimal", "precision": 12, "scale": 2 } }`))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, entry.Version)
+
+	// Settings of schema
+	entry, err = r.Register("orders", newSchema(t, `{
+	"$timezone": "Asia/Taipei",
+	"price": { "type": "decimal", "precision": 12, "scale": 2 }
+}`))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, entry.Version)
+
+	entry, err = r.Register("orders", newSchema(t, `{
+	"$timezone": "Asia/Taipei",
+	"$onError": "null",
+	"price": { "type": "decimal", "precision": 12, "scale": 2 }
+}`))
+	assert.Nil(t, err)
+	assert.Equal(t, 4, entry.Version)
+}
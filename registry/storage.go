@@ -0,0 +1,146 @@
+package registry
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BrobridgeOrg/schemer"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+type Storage interface {
+	Load() ([]*Entry, error)
+	Save(entry *Entry) error
+}
+
+// FileStorage persists every schema version as a JSON file in directory of subject
+type FileStorage struct {
+	dir string
+}
+
+type fileEntry struct {
+	ID      int64           `json:"id"`
+	Subject string          `json:"subject"`
+	Version int             `json:"version"`
+	Schema  *schemer.Schema `json:"schema"`
+}
+
+func NewFileStorage(dir string) *FileStorage {
+	return &FileStorage{
+		dir: dir,
+	}
+}
+
+func (fs *FileStorage) getSubjectDir(subject string) (string, error) {
+
+	// Subject must not point to outside of storage directory
+	if !isValidSubject(subject) {
+		return "", ErrInvalidSubject
+	}
+
+	return filepath.Join(fs.dir, url.PathEscape(subject)), nil
+}
+
+func (fs *FileStorage) Load() ([]*Entry, error) {
+
+	subjectDirs, err := os.ReadDir(fs.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*Entry{}, nil
+		}
+
+		return nil, err
+	}
+
+	entries := make([]*Entry, 0)
+	for _, subjectDir := range subjectDirs {
+
+		if !subjectDir.IsDir() {
+			continue
+		}
+
+		dir := filepath.Join(fs.dir, subjectDir.Name())
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+
+			if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+				continue
+			}
+
+			entry, err := fs.loadEntry(filepath.Join(dir, file.Name()))
+			if err != nil {
+				return nil, err
+			}
+
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+func (fs *FileStorage) loadEntry(filename string) (*Entry, error) {
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	fe := &fileEntry{
+		Schema: schemer.NewSchema(),
+	}
+
+	err = json.Unmarshal(data, fe)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load schema from %s: %w", filename, err)
+	}
+
+	return &Entry{
+		ID:      fe.ID,
+		Subject: fe.Subject,
+		Version: fe.Version,
+		Schema:  fe.Schema,
+	}, nil
+}
+
+func (fs *FileStorage) Save(entry *Entry) error {
+
+	dir, err := fs.getSubjectDir(entry.Subject)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(&fileEntry{
+		ID:      entry.ID,
+		Subject: entry.Subject,
+		Version: entry.Version,
+		Schema:  entry.Schema,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write to temporary file first to prevent broken file
+	filename := filepath.Join(dir, fmt.Sprintf("%d.json", entry.Version))
+	tmpFilename := filename + ".tmp"
+	err = os.WriteFile(tmpFilename, data, 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFilename, filename)
+}
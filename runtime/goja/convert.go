@@ -62,26 +62,92 @@ func (r *Runtime) normalize(schema *schemer.Schema, data map[string]interface{})
 			continue
 		}
 
-		if def.Type == schemer.TYPE_MAP {
-			r.normalize(def.Schema, val.(map[string]interface{}))
-			continue
+		data[fieldName] = r.convertValue(def, val)
+	}
+}
+
+// convertValue converts value of specific types for JavaScript
+func (r *Runtime) convertValue(def *schemer.Definition, val interface{}) interface{} {
+
+	// Select alternative of union
+	def = def.Resolve(val)
+	if def == nil {
+		return val
+	}
+
+	switch def.Type {
+	case schemer.TYPE_MAP:
+
+		if m, ok := val.(map[string]interface{}); ok {
+			r.normalize(def.Schema, m)
 		}
 
-		if def.Type == schemer.TYPE_TIME {
+	case schemer.TYPE_ARRAY:
 
-			// Skip null
-			if val == nil {
-				continue
+		// Elements are converted with subtype
+		if arr, ok := val.([]interface{}); ok && def.Subtype != nil {
+			result := make([]interface{}, len(arr))
+			for i, v := range arr {
+				result[i] = r.convertValue(def.Subtype, v)
 			}
 
-			if def.Info.(*types.Time).Precision != types.TIME_PRECISION_MICROSECOND {
+			return result
+		}
+
+	case schemer.TYPE_TIME:
 
-				// New Date object
-				dateValue := val.(time.Time).UnixMicro() / 1e3
-				v, _ := r.vm.New(r.vm.Get("Date").ToObject(r.vm), r.vm.ToValue(dateValue))
-				data[fieldName] = v
-			}
-			continue
+		// Skip null
+		v, ok := val.(time.Time)
+		if !ok {
+			break
+		}
+
+		if def.Info.(*types.Time).Precision != types.TIME_PRECISION_MICROSECOND {
+
+			// New Date object
+			dateValue := v.UnixMicro() / 1e3
+			d, _ := r.vm.New(r.vm.Get("Date").ToObject(r.vm), r.vm.ToValue(dateValue))
+			return d
+		}
+
+	case schemer.TYPE_DATE:
+
+		if d, ok := val.(types.Date); ok {
+			return d.String()
+		}
+
+	case schemer.TYPE_TIMEOFDAY:
+
+		if tod, ok := val.(types.TimeOfDay); ok {
+			return tod.String()
+		}
+
+	case schemer.TYPE_DURATION:
+
+		// Duration is represented as milliseconds which can be used with Date directly
+		if d, ok := val.(time.Duration); ok {
+			return float64(d) / float64(time.Millisecond)
+		}
+
+	case schemer.TYPE_ENUM:
+
+		if e, ok := val.(types.EnumValue); ok {
+			return e.Symbol
+		}
+
+	case schemer.TYPE_UUID:
+
+		if u, ok := val.(types.UUID); ok {
+			return u.String()
+		}
+
+	case schemer.TYPE_DECIMAL:
+
+		// Decimal is passed as string to prevent losing precision
+		if d, ok := val.(types.DecimalValue); ok {
+			return d.String()
 		}
 	}
+
+	return val
 }
@@ -4,12 +4,119 @@ import (
 	"fmt"
 	"log"
 	"reflect"
+	"time"
 
+	"github.com/BrobridgeOrg/schemer"
+	"github.com/BrobridgeOrg/schemer/types"
 	msgpack "github.com/vmihailenco/msgpack/v5"
 
 	"rogchap.com/v8go"
 )
 
+// normalize returns a copy of data which values of specific types are converted for JavaScript
+func (r *Runtime) normalize(schema *schemer.Schema, data map[string]interface{}) map[string]interface{} {
+
+	result := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		result[k] = v
+	}
+
+	for fieldName, def := range schema.Fields {
+
+		val, ok := data[fieldName]
+		if !ok {
+			continue
+		}
+
+		result[fieldName] = r.convertValue(def, val)
+	}
+
+	return result
+}
+
+// convertValue returns value of specific types converted for JavaScript
+func (r *Runtime) convertValue(def *schemer.Definition, val interface{}) interface{} {
+
+	// Select alternative of union
+	def = def.Resolve(val)
+	if def == nil {
+		return val
+	}
+
+	switch def.Type {
+	case schemer.TYPE_MAP:
+
+		if m, ok := val.(map[string]interface{}); ok {
+			return r.normalize(def.Schema, m)
+		}
+
+	case schemer.TYPE_ARRAY:
+
+		// Elements are converted with subtype
+		if arr, ok := val.([]interface{}); ok && def.Subtype != nil {
+			result := make([]interface{}, len(arr))
+			for i, v := range arr {
+				result[i] = r.convertValue(def.Subtype, v)
+			}
+
+			return result
+		}
+
+	case schemer.TYPE_TIME:
+
+		// Date object of JavaScript supports millisecond only
+		if t, ok := def.Info.(*types.Time); ok && t.Precision == types.TIME_PRECISION_MICROSECOND {
+			break
+		}
+
+		if v, ok := val.(time.Time); ok {
+			return &JSDate{
+				Time: v,
+			}
+		}
+
+	case schemer.TYPE_DATE:
+
+		if d, ok := val.(types.Date); ok {
+			return d.String()
+		}
+
+	case schemer.TYPE_TIMEOFDAY:
+
+		if tod, ok := val.(types.TimeOfDay); ok {
+			return tod.String()
+		}
+
+	case schemer.TYPE_DURATION:
+
+		// Duration is represented as milliseconds which can be used with Date directly
+		if d, ok := val.(time.Duration); ok {
+			return float64(d) / float64(time.Millisecond)
+		}
+
+	case schemer.TYPE_ENUM:
+
+		if e, ok := val.(types.EnumValue); ok {
+			return e.Symbol
+		}
+
+	case schemer.TYPE_UUID:
+
+		if u, ok := val.(types.UUID); ok {
+			return u.String()
+		}
+
+	case schemer.TYPE_DECIMAL:
+
+		// Decimal is passed as string to prevent losing precision
+		if d, ok := val.(types.DecimalValue); ok {
+			return d.String()
+		}
+	}
+
+	return val
+}
+
 func (r *Runtime) normalizeValue(data interface{}) interface{} {
 
 	v := reflect.ValueOf(data)
@@ -139,6 +246,8 @@ func (r *Runtime) convertAllBufferToBytes(data map[string]interface{}) interface
 			}
 		case *Uint8Array:
 			data[key] = v.Buffer.Bytes()
+		case *JSDate:
+			data[key] = v.Time
 		}
 	}
 
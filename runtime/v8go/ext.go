@@ -2,6 +2,7 @@ package v8go_runtime
 
 import (
 	"bytes"
+	"time"
 
 	msgpack "github.com/vmihailenco/msgpack/v5"
 )
@@ -32,7 +33,30 @@ func (u *Uint8Array) UnmarshalMsgpack(b []byte) error {
 	return err
 }
 
+// JSDate is Date object which is encoded as milliseconds since epoch by msgpack.js
+type JSDate struct {
+	Time time.Time
+}
+
+func (d JSDate) MarshalMsgpack() ([]byte, error) {
+	return msgpack.Marshal(float64(d.Time.UnixMilli()))
+}
+
+func (d *JSDate) UnmarshalMsgpack(b []byte) error {
+
+	var ms float64
+	err := msgpack.Unmarshal(b, &ms)
+	if err != nil {
+		return err
+	}
+
+	d.Time = time.UnixMilli(int64(ms))
+
+	return nil
+}
+
 func init() {
 	//msgpack.RegisterExt(18, (*Uint8Array)(nil))
+	msgpack.RegisterExt(13, new(JSDate))
 	msgpack.RegisterExt(18, new(Uint8Array))
 }
@@ -127,12 +127,11 @@ func (r *Runtime) Compile(script string) error {
 }
 
 func (r *Runtime) Execute(sourceSchema *schemer.Schema, data map[string]interface{}) ([]map[string]interface{}, error) {
-	/*
-		// Normalize data for JavaScript
-		if sourceSchema != nil {
-			r.normalize(sourceSchema, data)
-		}
-	*/
+
+	// Normalize data for JavaScript
+	if sourceSchema != nil {
+		data = r.normalize(sourceSchema, data)
+	}
 
 	//	fmt.Println(data["binary"].([]interface{})[0])
 
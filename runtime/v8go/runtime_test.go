@@ -0,0 +1,146 @@
+package v8go_runtime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BrobridgeOrg/schemer"
+	"github.com/BrobridgeOrg/schemer/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuntimeExecuteDecimal(t *testing.T) {
+
+	schema := schemer.NewSchema()
+	err := schemer.UnmarshalJSON([]byte(`{
+	"amount": { "type": "decimal", "precision": 30, "scale": 4 },
+	"count": { "type": "int" },
+	"object": {
+		"type": "map",
+		"fields": {
+			"price": { "type": "decimal", "precision": 10, "scale": 2 }
+		}
+	}
+}`), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	r := NewRuntime()
+	err = r.LoadScript(`
+function main(source) {
+	return {
+		amount: source.amount,
+		amountType: typeof source.amount,
+		count: source.count,
+		price: source.object.price
+	};
+}
+`)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	data := schema.Normalize(map[string]interface{}{
+		"amount": "12345678901234567890.1234",
+		"count":  10,
+		"object": map[string]interface{}{
+			"price": "9.90",
+		},
+	})
+
+	results, err := r.Execute(schema, data)
+	if !assert.Nil(t, err) || !assert.Len(t, results, 1) {
+		return
+	}
+
+	// Decimal is passed as string
+	assert.Equal(t, "12345678901234567890.1234", results[0]["amount"])
+	assert.Equal(t, "string", results[0]["amountType"])
+	assert.Equal(t, "9.90", results[0]["price"])
+	assert.EqualValues(t, 10, results[0]["count"])
+
+	// Data of caller is not modified
+	assert.IsType(t, types.DecimalValue{}, data["amount"])
+	assert.IsType(t, types.DecimalValue{}, data["object"].(map[string]interface{})["price"])
+	assert.Equal(t, int64(10), data["count"])
+}
+
+func TestRuntimeExecuteDuration(t *testing.T) {
+
+	schema := schemer.NewSchema()
+	err := schemer.UnmarshalJSON([]byte(`{
+	"retention": { "type": "duration" }
+}`), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	r := NewRuntime()
+	err = r.LoadScript(`
+function main(source) {
+	return {
+		retention: source.retention
+	};
+}
+`)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	data := schema.Normalize(map[string]interface{}{
+		"retention": "1h30m",
+	})
+
+	results, err := r.Execute(schema, data)
+	if !assert.Nil(t, err) || !assert.Len(t, results, 1) {
+		return
+	}
+
+	// Duration is passed as milliseconds
+	assert.EqualValues(t, 5400000, results[0]["retention"])
+}
+
+func TestRuntimeExecuteTime(t *testing.T) {
+
+	schema := schemer.NewSchema()
+	err := schemer.UnmarshalJSON([]byte(`{
+	"createdAt": { "type": "time" },
+	"retention": { "type": "duration" }
+}`), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	r := NewRuntime()
+	err = r.LoadScript(`
+function main(source) {
+	return {
+		isDate: source.createdAt instanceof Date,
+		createdAt: source.createdAt,
+		expiredAt: new Date(source.createdAt.getTime() + source.retention)
+	};
+}
+`)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	data := schema.Normalize(map[string]interface{}{
+		"createdAt": "2020-07-19T00:00:00Z",
+		"retention": "1h",
+	})
+
+	results, err := r.Execute(schema, data)
+	if !assert.Nil(t, err) || !assert.Len(t, results, 1) {
+		return
+	}
+
+	// Time is passed as Date object and returned as time
+	assert.Equal(t, true, results[0]["isDate"])
+	assert.Equal(t, time.Date(2020, time.July, 19, 0, 0, 0, 0, time.UTC), results[0]["createdAt"].(time.Time).UTC())
+	assert.Equal(t, time.Date(2020, time.July, 19, 1, 0, 0, 0, time.UTC), results[0]["expiredAt"].(time.Time).UTC())
+
+	// Data of caller is not modified
+	assert.IsType(t, time.Time{}, data["createdAt"])
+}
@@ -1,11 +1,25 @@
 package schemer
 
 import (
+	"fmt"
+	"sort"
 	"strings"
+	"time"
+
+	"github.com/BrobridgeOrg/schemer/types"
 )
 
 type Schema struct {
 	Fields map[string]*Definition
+
+	// Named definitions which can be shared by $ref
+	Defs map[string]*Definition
+
+	// Default time zone for timestamps without time zone
+	Timezone *time.Location
+
+	// Default policy for conversion failures
+	OnError ErrorPolicy
 }
 
 func NewSchema() *Schema {
@@ -75,13 +89,26 @@ func (s *Schema) GetDefinition(valuePath string) *Definition {
 }
 
 func (s *Schema) getDefinition(parts []string) *Definition {
+	return s.resolveDefinition(parts, nil)
+}
+
+// resolveDefinition finds definition by path. Data is used to select alternatives of union if it is available.
+func (s *Schema) resolveDefinition(parts []string, data interface{}) *Definition {
 
 	var def *Definition
+	var val interface{} = data
 	fields := s.Fields
 	for _, entry := range parts {
 
 		if def != nil {
 
+			if def.Type == TYPE_UNION {
+				def = resolveUnionBranch(def, val, entry)
+				if def == nil {
+					return nil
+				}
+			}
+
 			switch def.Type {
 			case TYPE_ARRAY:
 
@@ -104,7 +131,7 @@ func (s *Schema) getDefinition(parts []string) *Definition {
 		}
 
 		// Parse key and index
-		key, _ := parsePathEntry(entry)
+		key, index := parsePathEntry(entry)
 
 		// Check if we have a definition for this key
 		d, ok := fields[key]
@@ -114,12 +141,32 @@ func (s *Schema) getDefinition(parts []string) *Definition {
 		}
 
 		def = d
+		val = getChildValue(val, key, index)
 	}
 
 	return def
 }
 
-func (s *Schema) normalize(schema *Schema, data map[string]interface{}) map[string]interface{} {
+func getChildValue(data interface{}, key string, index int) interface{} {
+
+	m, ok := data.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	v := m[key]
+	if index == -1 {
+		return v
+	}
+
+	if list, ok := v.([]interface{}); ok && index < len(list) {
+		return list[index]
+	}
+
+	return nil
+}
+
+func (s *Schema) normalize(schema *Schema, path string, data map[string]interface{}, errs *[]*ConversionError) map[string]interface{} {
 
 	result := make(map[string]interface{}, len(data))
 
@@ -140,16 +187,54 @@ func (s *Schema) normalize(schema *Schema, data map[string]interface{}) map[stri
 		}
 
 		val, ok := data[fieldName]
-		if !ok {
-			continue
+		if !ok || val == nil {
+
+			// Apply default value
+			if def.Default != nil {
+				result[fieldName] = copyValue(def.Default)
+				continue
+			}
+
+			if !ok {
+				continue
+			}
 		}
 
 		if def.Type == TYPE_MAP && val != nil {
-			result[fieldName] = s.normalize(def.Schema, val.(map[string]interface{}))
+
+			m, ok := val.(map[string]interface{})
+			if ok {
+				result[fieldName] = s.normalize(def.Schema, joinPath(path, fieldName), m, errs)
+				continue
+			}
+
+			v, keep := s.reportError(joinPath(path, fieldName), def, val, nil, ErrInvalidType, errs)
+			if keep {
+				result[fieldName] = v
+			}
+
 			continue
 		}
 
-		v, _ := getValue(def, val)
+		// Alternative of union is normalized with the same error policy
+		if def.Type == TYPE_UNION && val != nil {
+			if branch := def.Resolve(val); branch != nil && branch.Type == TYPE_MAP {
+				if m, ok := val.(map[string]interface{}); ok {
+					result[fieldName] = s.normalizeUnionBranch(def, branch, joinPath(path, fieldName), m, errs)
+					continue
+				}
+			}
+		}
+
+		v, err := getValue(def, val)
+		if err != nil && val != nil {
+
+			var keep bool
+			v, keep = s.reportError(joinPath(path, fieldName), def, val, v, err, errs)
+			if !keep {
+				continue
+			}
+		}
 
 		result[fieldName] = v
 	}
@@ -177,7 +262,17 @@ func (s *Schema) normalize(schema *Schema, data map[string]interface{}) map[stri
 
 		v, err := getValue(def, val)
 		if err != nil {
-			continue
+
+			if val == nil {
+				continue
+			}
+
+			// Values set by path are dropped if no policy, as before
+			var keep bool
+			v, keep = s.reportError(key, def, val, v, err, errs)
+			if !keep || len(s.getErrorPolicy(def)) == 0 {
+				continue
+			}
 		}
 
 		result[key] = v
@@ -186,12 +281,126 @@ func (s *Schema) normalize(schema *Schema, data map[string]interface{}) map[stri
 	return result
 }
 
+// reportError records conversion error and applies error policy to the value
+func (s *Schema) reportError(path string, def *Definition, raw interface{}, converted interface{}, err error, errs *[]*ConversionError) (interface{}, bool) {
+
+	e := NewConversionError(path, def, raw, err)
+	e.Policy = s.getErrorPolicy(def)
+	*errs = append(*errs, e)
+
+	return applyErrorPolicy(e.Policy, def, raw, converted)
+}
+
 func (s *Schema) Normalize(data map[string]interface{}) map[string]interface{} {
-	return s.normalize(s, data)
+	result, _ := s.NormalizeWithErrors(data)
+	return result
+}
+
+// NormalizeWithErrors normalizes data and returns errors of values which cannot be converted. Errors are sorted by path.
+// Nil will be returned as result if any failed field has fail policy.
+func (s *Schema) NormalizeWithErrors(data map[string]interface{}) (map[string]interface{}, []*ConversionError) {
+
+	var errs []*ConversionError
+	result := s.normalize(s, "", data, &errs)
+
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Path < errs[j].Path
+	})
+
+	if getFailure(errs) != nil {
+		return nil, errs
+	}
+
+	return result, errs
 }
 
 func (s *Schema) Scan(data map[string]interface{}) *Record {
-	return NewRecord(s, s.normalize(s, data))
+	return NewRecord(s, s.Normalize(data))
+}
+
+// SetTimezone sets default time zone for all time fields which have no specific time zone
+func (s *Schema) SetTimezone(loc *time.Location) {
+
+	s.Timezone = loc
+
+	// Shared definitions might be recursive
+	visited := make(map[*Definition]bool)
+	for _, def := range s.Defs {
+		applyDefinitionTimezone(def, loc, visited)
+	}
+
+	applyTimezone(s, loc, visited)
+}
+
+func applyTimezone(s *Schema, loc *time.Location, visited map[*Definition]bool) {
+
+	if s == nil {
+		return
+	}
+
+	for _, def := range s.Fields {
+		applyDefinitionTimezone(def, loc, visited)
+	}
+}
+
+func applyDefinitionTimezone(def *Definition, loc *time.Location, visited map[*Definition]bool) {
+
+	if def == nil || visited[def] {
+		return
+	}
+
+	visited[def] = true
+
+	if t, ok := def.Info.(*types.Time); ok {
+		t.DefaultLocation = loc
+	}
+
+	applyTimezone(def.Schema, loc, visited)
+	applyDefinitionTimezone(def.Subtype, loc, visited)
+
+	for _, d := range def.Types {
+		applyDefinitionTimezone(d, loc, visited)
+	}
+}
+
+func (s *Schema) UnmarshalJSON(source []byte) error {
+
+	if s.Fields == nil {
+		s.Fields = make(map[string]*Definition)
+	}
+
+	return UnmarshalJSON(source, s)
+}
+
+func (s *Schema) MarshalJSON() ([]byte, error) {
+
+	doc := make(map[string]interface{}, len(s.Fields)+1)
+	for name, def := range s.Fields {
+		doc[name] = def.marshalField()
+	}
+
+	if s.Timezone != nil {
+		doc["$timezone"] = s.Timezone.String()
+	}
+
+	if len(s.OnError) > 0 {
+		doc["$onError"] = s.OnError
+	}
+
+	if len(s.Defs) > 0 {
+		defs := make(map[string]interface{}, len(s.Defs))
+		for name, def := range s.Defs {
+
+			// Generate body of shared definition instead of reference
+			body := *def
+			body.Ref = ""
+			defs[name] = body.marshalField()
+		}
+
+		doc["$defs"] = defs
+	}
+
+	return json.Marshal(doc)
 }
 
 func UnmarshalJSON(source []byte, s *Schema) error {
@@ -214,16 +423,78 @@ func UnmarshalJSON(source []byte, s *Schema) error {
 
 func Unmarshal(data map[string]interface{}, s *Schema) error {
 
+	// Named definitions
+	defs := s.Defs
+	if v, ok := data["$defs"]; ok {
+
+		d, err := unmarshalDefs(v)
+		if err != nil {
+			return err
+		}
+
+		// Keep definitions of schema
+		for name, def := range s.Defs {
+			if _, ok := d[name]; !ok {
+				d[name] = def
+			}
+		}
+
+		defs = d
+	}
+
+	var timezone *time.Location
 	for key, value := range data {
 
+		if key == "$defs" {
+			continue
+		}
+
+		// Default time zone of schema
+		if key == "$timezone" {
+
+			name, _ := value.(string)
+			loc, err := time.LoadLocation(name)
+			if err != nil || len(name) == 0 {
+				return fmt.Errorf("Unsupported timezone: %v", value)
+			}
+
+			timezone = loc
+			continue
+		}
+
+		// Default policy for conversion failures
+		if key == "$onError" {
+
+			p, err := parseErrorPolicy(value)
+			if err != nil {
+				return err
+			}
+
+			s.OnError = p
+			continue
+		}
+
 		// Parse definition from unknown interface object
-		var def Definition
-		err := UnmarshalDefinition(value, &def)
+		def, err := unmarshalDefinition(value)
 		if err != nil {
 			return err
 		}
 
-		s.Fields[key] = &def
+		s.Fields[key] = def
+	}
+
+	// Replace references with shared definitions
+	err := resolveRefs(s, defs)
+	if err != nil {
+		return err
+	}
+
+	if defs != nil {
+		s.Defs = defs
+	}
+
+	if timezone != nil {
+		s.SetTimezone(timezone)
 	}
 
 	return nil
@@ -2,9 +2,11 @@ package schemer
 
 import (
 	"bytes"
+	"math/big"
 	"testing"
 	"time"
 
+	"github.com/BrobridgeOrg/schemer/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -332,5 +334,708 @@ func TestSchema_MSSQL_Types(t *testing.T) {
 
 	// Scan raw data
 	_ = schema.Scan(rawData)
+}
+
+func TestSchemaNormalizeBinaryEncoding(t *testing.T) {
+
+	definition := `{
+	"raw": { "type": "binary" },
+	"base64": { "type": "binary", "encoding": "base64" },
+	"base64url": { "type": "binary", "encoding": "base64url" },
+	"hex": { "type": "binary", "encoding": "hex" },
+	"text": { "type": "string", "encoding": "hex" }
+}`
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(definition), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	result := schema.Normalize(map[string]interface{}{
+		"raw":       "Brobridge",
+		"base64":    "+/8=",
+		"base64url": "-_8",
+		"hex":       "0xFBFF",
+		"text":      []byte{0xfb, 0xff},
+	})
+
+	assert.Equal(t, []byte("Brobridge"), result["raw"])
+	assert.Equal(t, []byte{0xfb, 0xff}, result["base64"])
+	assert.Equal(t, []byte{0xfb, 0xff}, result["base64url"])
+	assert.Equal(t, []byte{0xfb, 0xff}, result["hex"])
+	assert.Equal(t, "fbff", result["text"])
+
+	// Invalid encoded string
+	violations := schema.Validate(map[string]interface{}{
+		"hex": "Brobridge",
+	})
+	if assert.Len(t, violations, 1) {
+		assert.Equal(t, VIOLATION_UNPARSABLE, violations[0].Reason)
+	}
+
+	result = schema.Normalize(map[string]interface{}{
+		"base64": "!!",
+	})
+
+	assert.Empty(t, result["base64"])
+
+	// Unsupported encoding
+	err = UnmarshalJSON([]byte(`{ "data": { "type": "binary", "encoding": "base32" } }`), NewSchema())
+	assert.NotNil(t, err)
+}
+
+func TestSchemaNormalizeWithConstraints(t *testing.T) {
+
+	definition := `{
+	"age": { "type": "int", "minimum": 0 },
+	"level": { "type": "int", "enum": [ 1, 2, 3 ] },
+	"name": { "type": "string", "maxLength": 4 }
+}`
+
+	data := map[string]interface{}{
+		"age":   float64(-1),
+		"level": float64(2),
+		"name":  "Brobridge",
+	}
+
+	// Initializing schema
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(definition), schema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.NotNil(t, schema.Fields["age"].Constraint)
+
+	result := schema.Normalize(data)
+
+	assert.Nil(t, result["age"])
+	assert.Equal(t, int64(2), result["level"])
+	assert.Nil(t, result["name"])
+
+	// Invalid constraint definition
+	err = UnmarshalJSON([]byte(`{ "code": { "type": "string", "pattern": "[" } }`), NewSchema())
+	assert.NotNil(t, err)
+}
+
+func TestSchemaNormalizeDecimal(t *testing.T) {
+
+	definition := `{
+	"amount": { "type": "decimal", "precision": 10, "scale": 2 },
+	"rate": { "type": "decimal" },
+	"raw": { "type": "decimal", "scale": 2 },
+	"overflow": { "type": "decimal", "precision": 4, "scale": 2 },
+	"price": { "type": "string" }
+}`
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(definition), schema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, TYPE_DECIMAL, schema.Fields["amount"].Type)
+
+	result := schema.Normalize(map[string]interface{}{
+		"amount":   "12345.675",
+		"rate":     float64(0.1),
+		"raw":      []byte{0xcf, 0xc7},
+		"overflow": int64(100),
+		"price":    types.NewDecimalValue(big.NewInt(1050), 2),
+	})
+
+	assert.Equal(t, "12345.68", result["amount"].(types.DecimalValue).String())
+	assert.Equal(t, "0.1", result["rate"].(types.DecimalValue).String())
+	assert.Equal(t, "-123.45", result["raw"].(types.DecimalValue).String())
+	assert.Nil(t, result["overflow"])
+	assert.Equal(t, "10.50", result["price"])
+
+	// Encoded as exact number
+	data, _ := json.Marshal(result["amount"])
+	assert.Equal(t, "12345.68", string(data))
+
+	// Scale cannot be greater than precision
+	err = UnmarshalJSON([]byte(`{ "amount": { "type": "decimal", "precision": 2, "scale": 4 } }`), NewSchema())
+	assert.NotNil(t, err)
+}
+
+func TestSchemaNormalizeDecimalExponent(t *testing.T) {
+
+	definition := `{
+	"amount": { "type": "decimal", "precision": 10, "scale": 2 },
+	"rate": { "type": "decimal" }
+}`
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(definition), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	start := time.Now()
+
+	result := schema.Normalize(map[string]interface{}{
+		"amount": "1e50000000",
+		"rate":   "1e-50000000",
+	})
+
+	assert.Nil(t, result["amount"])
+	assert.Nil(t, result["rate"])
+
+	violations := schema.Validate(map[string]interface{}{
+		"amount": "1e50000000",
+		"rate":   "-1E2147483647",
+	})
+	if assert.Len(t, violations, 2) {
+		assert.Equal(t, VIOLATION_OUT_OF_RANGE, violations[0].Reason)
+		assert.Equal(t, VIOLATION_OUT_OF_RANGE, violations[1].Reason)
+	}
+
+	// Huge exponent is rejected before expanding digits
+	assert.Less(t, time.Since(start), time.Second)
+
+	_, err = types.ParseDecimalValue("1e50000000")
+	assert.Equal(t, types.ErrOutOfRange, err)
+
+	// Exponent within limit
+	d, err := types.ParseDecimalValue("1.5e3")
+	if assert.Nil(t, err) {
+		assert.Equal(t, "1500", d.String())
+	}
+}
+
+func TestSchemaNormalizeUUID(t *testing.T) {
+
+	definition := `{
+	"id": { "type": "uuid" },
+	"raw": { "type": "uuid" },
+	"encoded": { "type": "uuid" },
+	"compact": { "type": "uuid" },
+	"invalid": { "type": "uuid" },
+	"text": { "type": "string" },
+	"binary": { "type": "binary" }
+}`
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(definition), schema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	raw := []byte{
+		0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3,
+		0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00,
+	}
+
+	id, _ := types.ParseUUID("123e4567-e89b-12d3-a456-426614174000")
+
+	result := schema.Normalize(map[string]interface{}{
+		"id":      "123E4567-E89B-12D3-A456-426614174000",
+		"raw":     raw,
+		"encoded": "Ej5FZ+ibEtOkVkJmFBdAAA==",
+		"compact": "123e4567e89b12d3a456426614174000",
+		"invalid": "123e4567-e89b",
+		"text":    id,
+		"binary":  id,
+	})
+
+	expected := "123e4567-e89b-12d3-a456-426614174000"
+	assert.Equal(t, expected, result["id"].(types.UUID).String())
+	assert.Equal(t, expected, result["raw"].(types.UUID).String())
+	assert.Equal(t, expected, result["encoded"].(types.UUID).String())
+	assert.Equal(t, expected, result["compact"].(types.UUID).String())
+	assert.Nil(t, result["invalid"])
+	assert.Equal(t, expected, result["text"])
+	assert.Equal(t, raw, result["binary"])
+
+	// Validation
+	violations := schema.Validate(map[string]interface{}{
+		"id":  "not-a-uuid",
+		"raw": raw,
+	})
+	if assert.Len(t, violations, 1) {
+		assert.Equal(t, "id", violations[0].Path)
+		assert.Equal(t, VIOLATION_UNPARSABLE, violations[0].Reason)
+	}
+}
+
+func TestSchemaNormalizeDateAndTimeOfDay(t *testing.T) {
+
+	definition := `{
+	"birthday": { "type": "date" },
+	"epochDays": { "type": "date" },
+	"createdDate": { "type": "date" },
+	"openAt": { "type": "timeofday" },
+	"closeAt": { "type": "timeofday" },
+	"elapsed": { "type": "timeofday", "precision": "millisecond" },
+	"overflow": { "type": "timeofday" },
+	"timestamp": { "type": "time" },
+	"dateText": { "type": "string" },
+	"timeText": { "type": "string" }
+}`
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(definition), schema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	result := schema.Normalize(map[string]interface{}{
+		"birthday":    "2020/07/19",
+		"epochDays":   float64(18462),
+		"createdDate": "2020-07-19T18:16:08Z",
+		"openAt":      "0001-01-01T15:30:00Z",
+		"closeAt":     "9:05:30.25 PM",
+		"elapsed":     int64(3723004),
+		"overflow":    float64(86400),
+		"timestamp":   types.Date{Year: 2020, Month: time.July, Day: 19},
+		"dateText":    types.Date{Year: 2020, Month: time.July, Day: 19},
+		"timeText":    types.TimeOfDay(15*time.Hour + 30*time.Minute),
+	})
+
+	assert.Equal(t, "2020-07-19", result["birthday"].(types.Date).String())
+	assert.Equal(t, "2020-07-19", result["epochDays"].(types.Date).String())
+	assert.Equal(t, "2020-07-19", result["createdDate"].(types.Date).String())
+	assert.Equal(t, "15:30:00", result["openAt"].(types.TimeOfDay).String())
+	assert.Equal(t, "21:05:30.25", result["closeAt"].(types.TimeOfDay).String())
+	assert.Equal(t, "01:02:03.004", result["elapsed"].(types.TimeOfDay).String())
+	assert.Nil(t, result["overflow"])
+	assert.Equal(t, time.Date(2020, time.July, 19, 0, 0, 0, 0, time.UTC), result["timestamp"])
+	assert.Equal(t, "2020-07-19", result["dateText"])
+	assert.Equal(t, "15:30:00", result["timeText"])
+
+	// Validation
+	violations := schema.Validate(map[string]interface{}{
+		"birthday": "yesterday",
+		"openAt":   float64(-1),
+	})
+	if assert.Len(t, violations, 2) {
+		assert.Equal(t, VIOLATION_UNPARSABLE, violations[0].Reason)
+		assert.Equal(t, VIOLATION_OUT_OF_RANGE, violations[1].Reason)
+	}
+}
+
+func TestSchemaNormalizeDuration(t *testing.T) {
+
+	definition := `{
+	"timeout": { "type": "duration" },
+	"retention": { "type": "duration" },
+	"latency": { "type": "duration", "unit": "ms" },
+	"interval": { "type": "duration" },
+	"invalid": { "type": "duration" },
+	"seconds": { "type": "float" },
+	"text": { "type": "string" }
+}`
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(definition), schema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	result := schema.Normalize(map[string]interface{}{
+		"timeout":   "1h30m",
+		"retention": "P7DT12H",
+		"latency":   float64(250),
+		"interval":  "PT0.5S",
+		"invalid":   "forever",
+		"seconds":   90 * time.Second,
+		"text":      90 * time.Second,
+	})
+
+	assert.Equal(t, 90*time.Minute, result["timeout"])
+	assert.Equal(t, 7*24*time.Hour+12*time.Hour, result["retention"])
+	assert.Equal(t, 250*time.Millisecond, result["latency"])
+	assert.Equal(t, 500*time.Millisecond, result["interval"])
+	assert.Nil(t, result["invalid"])
+	assert.Equal(t, float64(90), result["seconds"])
+	assert.Equal(t, "1m30s", result["text"])
+
+	// Unsupported unit
+	err = UnmarshalJSON([]byte(`{ "latency": { "type": "duration", "unit": "day" } }`), NewSchema())
+	assert.NotNil(t, err)
+}
+
+func TestSchemaNormalizeEnum(t *testing.T) {
+
+	definition := `{
+	"status": { "type": "enum", "symbols": [ "ACTIVE", "INACTIVE" ] },
+	"level": { "type": "enum", "symbols": [ "LOW", "HIGH" ], "codes": [ 10, 20 ] },
+	"levelCode": { "type": "enum", "symbols": [ "LOW", "HIGH" ], "codes": [ 10, 20 ] },
+	"unknown": { "type": "enum", "symbols": [ "ACTIVE", "INACTIVE" ] },
+	"code": { "type": "int" },
+	"name": { "type": "string" }
+}`
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(definition), schema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	high := types.EnumValue{Symbol: "HIGH", Code: 20}
+
+	result := schema.Normalize(map[string]interface{}{
+		"status":    "inactive",
+		"level":     float64(20),
+		"levelCode": "10",
+		"unknown":   "DELETED",
+		"code":      high,
+		"name":      high,
+	})
+
+	assert.Equal(t, types.EnumValue{Symbol: "INACTIVE", Code: 1}, result["status"])
+	assert.Equal(t, high, result["level"])
+	assert.Equal(t, "LOW", result["levelCode"].(types.EnumValue).Symbol)
+	assert.Nil(t, result["unknown"])
+	assert.Equal(t, int64(20), result["code"])
+	assert.Equal(t, "HIGH", result["name"])
+
+	// Validation
+	violations := schema.Validate(map[string]interface{}{
+		"status": "DELETED",
+		"level":  float64(30),
+	})
+	if assert.Len(t, violations, 2) {
+		assert.Equal(t, "level", violations[0].Path)
+		assert.Equal(t, VIOLATION_ENUM, violations[0].Reason)
+		assert.Equal(t, "status", violations[1].Path)
+		assert.Equal(t, VIOLATION_ENUM, violations[1].Reason)
+	}
+
+	// Invalid definitions
+	err = UnmarshalJSON([]byte(`{ "status": { "type": "enum" } }`), NewSchema())
+	assert.NotNil(t, err)
+
+	err = UnmarshalJSON([]byte(`{ "status": { "type": "enum", "symbols": [ "A", "a" ] } }`), NewSchema())
+	assert.NotNil(t, err)
+
+	err = UnmarshalJSON([]byte(`{ "status": { "type": "enum", "symbols": [ "A", "B" ], "codes": [ 1 ] } }`), NewSchema())
+	assert.NotNil(t, err)
+}
+
+func TestSchemaNormalizeTimeFormat(t *testing.T) {
+
+	definition := `{
+	"createdAt": { "type": "time", "format": "02/01/2006 15:04" },
+	"updatedAt": { "type": "time", "format": [ "%Y%m%d %H%M%S", "RFC1123", "%d %b %Y" ] },
+	"deletedAt": { "type": "time", "format": "%Y-%m-%d" },
+	"date": { "type": "string", "format": "%Y/%m/%d %H:%M:%S.%L" },
+	"rfc": { "type": "string", "format": "RFC1123Z" },
+	"text": { "type": "string" }
+}`
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(definition), schema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ts := time.Date(2020, time.July, 19, 18, 16, 8, 123000000, time.UTC)
+
+	result := schema.Normalize(map[string]interface{}{
+		"createdAt": "19/07/2020 18:16",
+		"updatedAt": "19 Jul 2020",
+		"deletedAt": "2020-07-19T18:16:08Z",
+		"date":      ts,
+		"rfc":       ts,
+		"text":      ts,
+	})
+
+	assert.Equal(t, time.Date(2020, time.July, 19, 18, 16, 0, 0, time.UTC), result["createdAt"])
+	assert.Equal(t, time.Date(2020, time.July, 19, 0, 0, 0, 0, time.UTC), result["updatedAt"])
+	assert.Equal(t, ts.Truncate(time.Second), result["deletedAt"])
+	assert.Equal(t, "2020/07/19 18:16:08.123", result["date"])
+	assert.Equal(t, "Sun, 19 Jul 2020 18:16:08 +0000", result["rfc"])
+	assert.Equal(t, "2020-07-19T18:16:08.123Z", result["text"])
+
+	assert.Equal(t, []string{"20060102 150405", time.RFC1123, "02 Jan 2006"}, schema.Fields["updatedAt"].Info.(*types.Time).Layouts)
+
+	// Unsupported directive
+	err = UnmarshalJSON([]byte(`{ "createdAt": { "type": "time", "format": "%Q" } }`), NewSchema())
+	assert.NotNil(t, err)
+}
+
+func TestSchemaNormalizeWithDefault(t *testing.T) {
+
+	definition := `{
+	"name": { "type": "string", "default": "unknown" },
+	"level": { "type": "int", "default": "3" },
+	"enabled": { "type": "bool", "default": true },
+	"createdAt": { "type": "time", "default": "2020-07-19T18:16:08Z" },
+	"tags": { "type": "array", "subtype": "string", "default": [ "a", "b" ] },
+	"attributes": {
+		"type": "map",
+		"fields": {
+			"title": { "type": "string" },
+			"team": { "type": "string", "default": "product" }
+		},
+		"default": {
+			"title": "Architect"
+		}
+	}
+}`
+
+	data := map[string]interface{}{
+		"name":    nil,
+		"enabled": false,
+	}
+
+	// Initializing schema
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(definition), schema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	result := schema.Normalize(data)
+
+	assert.Equal(t, "unknown", result["name"])
+	assert.Equal(t, int64(3), result["level"])
+	assert.Equal(t, false, result["enabled"])
+	assert.Equal(t, int64(1595182568), result["createdAt"].(time.Time).Unix())
+	assert.Equal(t, []interface{}{"a", "b"}, result["tags"])
+
+	attrs := result["attributes"].(map[string]interface{})
+	assert.Equal(t, "Architect", attrs["title"])
+	assert.Equal(t, "product", attrs["team"])
+
+	// Default value should not be shared between records
+	attrs["title"] = "Engineer"
+	result = schema.Normalize(map[string]interface{}{})
+	assert.Equal(t, "Architect", result["attributes"].(map[string]interface{})["title"])
+
+	// Nested default
+	result = schema.Normalize(map[string]interface{}{
+		"attributes": map[string]interface{}{
+			"title": "Engineer",
+		},
+	})
+	attrs = result["attributes"].(map[string]interface{})
+	assert.Equal(t, "Engineer", attrs["title"])
+	assert.Equal(t, "product", attrs["team"])
+
+	// Invalid default value
+	err = UnmarshalJSON([]byte(`{ "level": { "type": "int", "minimum": 5, "default": 1 } }`), NewSchema())
+	assert.Equal(t, ErrInvalidDefaultDefinition, err)
+}
+
+func TestSchemaMarshalJSON(t *testing.T) {
+
+	source := `{
+	"name": { "type": "string", "notNull": true, "description": "Full name" },
+	"createdAt": { "type": "time", "precision": "microsecond" },
+	"updatedAt": { "type": "time" },
+	"tags": {
+		"type": "array",
+		"subtype": "string"
+	},
+	"attachments": {
+		"type": "array",
+		"subtype": "map",
+		"fields": {
+			"filename": { "type": "string" },
+			"size": { "type": "int", "minimum": 0 }
+		}
+	},
+	"attributes": {
+		"type": "map",
+		"fields": {
+			"title": { "type": "string", "default": "Architect" }
+		}
+	}
+}`
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(source), schema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	data, err := json.Marshal(schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	var doc map[string]interface{}
+	json.Unmarshal(data, &doc)
+
+	name := doc["name"].(map[string]interface{})
+	assert.Equal(t, "string", name["type"])
+	assert.Equal(t, true, name["notNull"])
+	assert.Equal(t, "Full name", name["description"])
+
+	createdAt := doc["createdAt"].(map[string]interface{})
+	assert.Equal(t, "microsecond", createdAt["precision"])
+	assert.NotContains(t, doc["updatedAt"], "precision")
+
+	tags := doc["tags"].(map[string]interface{})
+	assert.Equal(t, "string", tags["subtype"])
+
+	attachments := doc["attachments"].(map[string]interface{})
+	subtype := attachments["subtype"].(map[string]interface{})
+	assert.Equal(t, "map", subtype["type"])
+	assert.Contains(t, subtype["fields"], "filename")
+
+	// Round trip
+	restored := NewSchema()
+	err = json.Unmarshal(data, restored)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, TYPE_STRING, restored.Fields["name"].Type)
+	assert.True(t, restored.Fields["name"].NotNull)
+	assert.Equal(t, "Full name", restored.Fields["name"].Props["description"])
+	assert.Equal(t, types.TIME_PRECISION_MICROSECOND, restored.Fields["createdAt"].Info.(*types.Time).Precision)
+	assert.Equal(t, TYPE_STRING, restored.Fields["tags"].Subtype.Type)
+	assert.Equal(t, TYPE_MAP, restored.Fields["attachments"].Subtype.Type)
+	assert.Equal(t, TYPE_INT64, restored.Fields["attachments"].Subtype.Schema.Fields["size"].Type)
+	assert.NotNil(t, restored.Fields["attachments"].Subtype.Schema.Fields["size"].Constraint)
+	assert.Equal(t, "Architect", restored.Fields["attributes"].Schema.Fields["title"].Default)
+
+	// Definition built in Go
+	def := NewDefinition(TYPE_ARRAY)
+	def.Subtype = NewDefinition(TYPE_INT64)
+	data, err = json.Marshal(def)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{ "type": "array", "subtype": "int" }`, string(data))
+}
+
+func TestSchemaMarshalJSON_DefinitionBuiltInGo(t *testing.T) {
+
+	minimum := float64(0)
+	maxLength := 8
+
+	schema := NewSchema()
+
+	opensAt := NewDefinition(TYPE_TIMEOFDAY)
+	opensAt.Info = &types.Time{Precision: types.TIME_PRECISION_MICROSECOND}
+	schema.Fields["opensAt"] = opensAt
+
+	price := NewDefinition(TYPE_DECIMAL)
+	price.Info = &types.Decimal{Precision: 10, Scale: 2}
+	price.NotNull = true
+	price.Constraint = &types.Constraint{Minimum: &minimum}
+	schema.Fields["price"] = price
+
+	code := NewDefinition(TYPE_STRING)
+	code.Constraint = &types.Constraint{MaxLength: &maxLength}
+	code.Default = "N/A"
+	code.OnError = ERROR_POLICY_DEFAULT
+	schema.Fields["code"] = code
+
+	status := NewDefinition(TYPE_ENUM)
+	status.Info = &types.Enum{Symbols: []string{"ACTIVE", "INACTIVE"}, Codes: []int64{1, 2}}
+	schema.Fields["status"] = status
+
+	retention := NewDefinition(TYPE_DURATION)
+	retention.Info = &types.Duration{Unit: time.Millisecond}
+	retention.Default = time.Hour
+	schema.Fields["retention"] = retention
+
+	checksum := NewDefinition(TYPE_BINARY)
+	checksum.Info = &types.Binary{Encoding: types.BINARY_ENCODING_HEX}
+	schema.Fields["checksum"] = checksum
+
+	data, err := schema.MarshalJSON()
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// Round trip
+	restored := NewSchema()
+	err = UnmarshalJSON(data, restored)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, TYPE_TIMEOFDAY, restored.Fields["opensAt"].Type)
+	assert.Equal(t, types.TIME_PRECISION_MICROSECOND, restored.Fields["opensAt"].Info.(*types.Time).Precision)
+
+	assert.True(t, restored.Fields["price"].NotNull)
+	assert.Equal(t, &types.Decimal{Precision: 10, Scale: 2}, restored.Fields["price"].Info)
+	assert.Equal(t, minimum, *restored.Fields["price"].Constraint.Minimum)
+
+	assert.Equal(t, maxLength, *restored.Fields["code"].Constraint.MaxLength)
+	assert.Equal(t, "N/A", restored.Fields["code"].Default)
+	assert.Equal(t, ERROR_POLICY_DEFAULT, restored.Fields["code"].OnError)
+
+	assert.Equal(t, status.Info, restored.Fields["status"].Info)
+
+	assert.Equal(t, time.Millisecond, restored.Fields["retention"].Info.(*types.Duration).Unit)
+	assert.Equal(t, time.Hour, restored.Fields["retention"].Default)
+
+	assert.Equal(t, types.BINARY_ENCODING_HEX, restored.Fields["checksum"].Info.(*types.Binary).Encoding)
+
+	// Same document is generated again
+	regenerated, err := restored.MarshalJSON()
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.JSONEq(t, string(data), string(regenerated))
+}
+
+func TestSchemaNormalizeTimezone(t *testing.T) {
+
+	definition := `{
+	"$timezone": "Asia/Taipei",
+	"createdAt": { "type": "time" },
+	"updatedAt": { "type": "time", "timezone": "America/New_York" },
+	"utc": { "type": "time", "format": "2006-01-02 15:04:05" },
+	"local": { "type": "string", "timezone": "Asia/Tokyo", "format": "2006-01-02 15:04:05" }
+}`
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(definition), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	taipei, _ := time.LoadLocation("Asia/Taipei")
+	newYork, _ := time.LoadLocation("America/New_York")
+	assert.Equal(t, taipei, schema.Timezone)
+
+	result := schema.Normalize(map[string]interface{}{
+		"createdAt": "2020-07-19 18:16:08",
+		"updatedAt": "2020-07-19 18:16:08",
+		"utc":       "2020-07-19 18:16:08",
+		"local":     time.Date(2020, time.July, 19, 0, 0, 0, 0, time.UTC),
+	})
+
+	assert.True(t, time.Date(2020, time.July, 19, 18, 16, 8, 0, taipei).Equal(result["createdAt"].(time.Time)))
+	assert.True(t, time.Date(2020, time.July, 19, 18, 16, 8, 0, newYork).Equal(result["updatedAt"].(time.Time)))
+	assert.True(t, time.Date(2020, time.July, 19, 18, 16, 8, 0, taipei).Equal(result["utc"].(time.Time)))
+	assert.Equal(t, "2020-07-19 09:00:00", result["local"])
+
+	// Timestamps with time zone are not affected
+	result = schema.Normalize(map[string]interface{}{
+		"createdAt": "2020-07-19T18:16:08Z",
+	})
+
+	assert.Equal(t, time.Date(2020, time.July, 19, 18, 16, 8, 0, time.UTC), result["createdAt"])
+
+	// Default time zone is kept
+	data, err := schema.MarshalJSON()
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	restored := NewSchema()
+	err = UnmarshalJSON(data, restored)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, taipei, restored.Timezone)
+	assert.Equal(t, "America/New_York", restored.Fields["updatedAt"].Props["timezone"])
+
+	// Invalid time zone
+	err = UnmarshalJSON([]byte(`{ "createdAt": { "type": "time", "timezone": "Mars/Olympus" } }`), NewSchema())
+	assert.NotNil(t, err)
 
+	err = UnmarshalJSON([]byte(`{ "$timezone": "Mars/Olympus" }`), NewSchema())
+	assert.NotNil(t, err)
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/BrobridgeOrg/schemer"
 	goja_runtime "github.com/BrobridgeOrg/schemer/runtime/goja"
 	v8go_runtime "github.com/BrobridgeOrg/schemer/runtime/v8go"
+	"github.com/BrobridgeOrg/schemer/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -1272,3 +1273,314 @@ func TestTransformer_Source_TimeEmptyString(t *testing.T) {
 
 	assert.Equal(t, nil, result["time"])
 }
+
+func TestTransformer_Dest_Default(t *testing.T) {
+
+	sourceSchema := schemer.NewSchema()
+	err := schemer.UnmarshalJSON([]byte(testSource), sourceSchema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	destSchema := schemer.NewSchema()
+	err = schemer.UnmarshalJSON([]byte(`{
+	"string": { "type": "string" },
+	"int": { "type": "int", "default": 9527 },
+	"time": { "type": "time", "default": 1595182568 }
+}`), destSchema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Create transformer
+	transformer := schemer.NewTransformer(sourceSchema, destSchema,
+		schemer.WithRuntime(jsRuntime),
+	)
+
+	// Set transform script
+	transformer.SetScript(`
+	return {
+		"string": source.string,
+		"int": null
+	}
+`)
+
+	// Transform
+	rawData := `{
+	"string": "Brobridge"
+}`
+	var sourceData map[string]interface{}
+	err = json.Unmarshal([]byte(rawData), &sourceData)
+	if err != nil {
+		t.Error(err)
+	}
+
+	results, err := transformer.Transform(nil, sourceData)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(results) != 1 {
+		t.Fail()
+	}
+
+	result := results[0]
+
+	assert.Equal(t, "Brobridge", result["string"].(string))
+	assert.Equal(t, int64(9527), result["int"].(int64))
+	assert.Equal(t, int64(1595182568), result["time"].(time.Time).Unix())
+}
+
+func TestTransformer_Decimal(t *testing.T) {
+
+	sourceSchema := schemer.NewSchema()
+	err := schemer.UnmarshalJSON([]byte(`{
+	"amount": { "type": "decimal", "precision": 30, "scale": 4 }
+}`), sourceSchema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	destSchema := schemer.NewSchema()
+	err = schemer.UnmarshalJSON([]byte(`{
+	"amount": { "type": "decimal", "precision": 30, "scale": 4 },
+	"text": { "type": "string" }
+}`), destSchema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Create transformer
+	transformer := schemer.NewTransformer(sourceSchema, destSchema,
+		schemer.WithRuntime(jsRuntime),
+	)
+
+	// Set transform script
+	transformer.SetScript(`
+	return {
+		"amount": source.amount,
+		"text": typeof source.amount
+	}
+`)
+
+	results, err := transformer.Transform(nil, map[string]interface{}{
+		"amount": "12345678901234567890.1234",
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(results) != 1 {
+		t.Fail()
+	}
+
+	result := results[0]
+
+	assert.Equal(t, "12345678901234567890.1234", result["amount"].(types.DecimalValue).String())
+	assert.Equal(t, "string", result["text"])
+}
+
+func TestTransformer_DecimalArray(t *testing.T) {
+
+	sourceSchema := schemer.NewSchema()
+	err := schemer.UnmarshalJSON([]byte(`{
+	"prices": { "type": "array", "subtype": "decimal" }
+}`), sourceSchema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	destSchema := schemer.NewSchema()
+	err = schemer.UnmarshalJSON([]byte(`{
+	"prices": { "type": "array", "subtype": "decimal" },
+	"types": { "type": "array", "subtype": "string" }
+}`), destSchema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Create transformer
+	transformer := schemer.NewTransformer(sourceSchema, destSchema,
+		schemer.WithRuntime(jsRuntime),
+	)
+
+	// Set transform script
+	transformer.SetScript(`
+	return {
+		"prices": source.prices,
+		"types": source.prices.map(function(price) {
+			return typeof price;
+		})
+	}
+`)
+
+	results, err := transformer.Transform(nil, map[string]interface{}{
+		"prices": []interface{}{"12345678901234567890.1234", "9.90"},
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(results) != 1 {
+		t.Fail()
+	}
+
+	result := results[0]
+
+	// Elements are passed as string like decimal field
+	prices := result["prices"].([]interface{})
+	assert.Equal(t, "12345678901234567890.1234", prices[0].(types.DecimalValue).String())
+	assert.Equal(t, "9.90", prices[1].(types.DecimalValue).String())
+	assert.Equal(t, []interface{}{"string", "string"}, result["types"])
+}
+
+func TestTransformer_DateAndTimeOfDay(t *testing.T) {
+
+	sourceSchema := schemer.NewSchema()
+	err := schemer.UnmarshalJSON([]byte(`{
+	"date": { "type": "date" },
+	"time": { "type": "timeofday" }
+}`), sourceSchema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	destSchema := schemer.NewSchema()
+	err = schemer.UnmarshalJSON([]byte(`{
+	"date": { "type": "date" },
+	"time": { "type": "timeofday" },
+	"timestamp": { "type": "time" }
+}`), destSchema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Create transformer
+	transformer := schemer.NewTransformer(sourceSchema, destSchema,
+		schemer.WithRuntime(jsRuntime),
+	)
+
+	// Set transform script
+	transformer.SetScript(`
+	return {
+		"date": source.date,
+		"time": source.time,
+		"timestamp": new Date(source.date + 'T' + source.time + 'Z')
+	}
+`)
+
+	results, err := transformer.Transform(nil, map[string]interface{}{
+		"date": "2020-07-19",
+		"time": "0001-01-01T15:30:00Z",
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(results) != 1 {
+		t.Fail()
+	}
+
+	result := results[0]
+
+	assert.Equal(t, "2020-07-19", result["date"].(types.Date).String())
+	assert.Equal(t, "15:30:00", result["time"].(types.TimeOfDay).String())
+	assert.Equal(t, time.Date(2020, time.July, 19, 15, 30, 0, 0, time.UTC), result["timestamp"].(time.Time).UTC())
+}
+
+func TestTransformer_Duration(t *testing.T) {
+
+	sourceSchema := schemer.NewSchema()
+	err := schemer.UnmarshalJSON([]byte(`{
+	"createdAt": { "type": "time" },
+	"retention": { "type": "duration" }
+}`), sourceSchema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	destSchema := schemer.NewSchema()
+	err = schemer.UnmarshalJSON([]byte(`{
+	"expiredAt": { "type": "time" },
+	"retention": { "type": "duration", "unit": "ms" },
+	"text": { "type": "duration" },
+	"elapsed": { "type": "duration", "unit": "ms" }
+}`), destSchema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Create transformer
+	transformer := schemer.NewTransformer(sourceSchema, destSchema,
+		schemer.WithRuntime(jsRuntime),
+	)
+
+	// Set transform script
+	transformer.SetScript(`
+	let expiredAt = Duration.add(source.createdAt, source.retention);
+	return {
+		"expiredAt": expiredAt,
+		"retention": source.retention,
+		"text": Duration.format(source.retention + Duration.parse('PT30M')),
+		"elapsed": Duration.between(source.createdAt, expiredAt)
+	}
+`)
+
+	results, err := transformer.Transform(nil, map[string]interface{}{
+		"createdAt": "2020-07-19T00:00:00Z",
+		"retention": "P1D",
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(results) != 1 {
+		t.Fail()
+	}
+
+	result := results[0]
+
+	assert.Equal(t, time.Date(2020, time.July, 20, 0, 0, 0, 0, time.UTC), result["expiredAt"].(time.Time).UTC())
+	assert.Equal(t, 24*time.Hour, result["retention"])
+	assert.Equal(t, 24*time.Hour+30*time.Minute, result["text"])
+	assert.Equal(t, 24*time.Hour, result["elapsed"])
+}
+
+func TestTransformer_ErrorPolicy(t *testing.T) {
+
+	sourceSchema := schemer.NewSchema()
+	err := schemer.UnmarshalJSON([]byte(`{
+	"createdAt": { "type": "time", "onError": "fail" },
+	"count": { "type": "int", "onError": "null" }
+}`), sourceSchema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Create transformer
+	transformer := schemer.NewTransformer(sourceSchema, nil,
+		schemer.WithRuntime(jsRuntime),
+	)
+
+	transformer.SetScript(`return source`)
+
+	results, err := transformer.Transform(nil, map[string]interface{}{
+		"createdAt": "2020-07-19T00:00:00Z",
+		"count":     "many",
+	})
+	if !assert.Nil(t, err) || !assert.Len(t, results, 1) {
+		return
+	}
+
+	assert.Nil(t, results[0]["count"])
+
+	// Record is rejected
+	results, err = transformer.Transform(nil, map[string]interface{}{
+		"createdAt": "yesterday",
+	})
+
+	assert.Nil(t, results)
+	if assert.IsType(t, &schemer.ConversionError{}, err) {
+		assert.Equal(t, "createdAt", err.(*schemer.ConversionError).Path)
+	}
+}
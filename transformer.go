@@ -50,17 +50,24 @@ func (t *Transformer) normalizeValue(v map[string]interface{}) (map[string]inter
 
 	var val map[string]interface{}
 
+	var errs []*ConversionError
+
 	// Normalize for destination schema if it exists
 	if t.dest != nil {
-		val = t.dest.Normalize(v)
+		val, errs = t.dest.NormalizeWithErrors(v)
 	} else if t.source != nil {
 
 		// Inherit source schema
-		val = t.source.Normalize(v)
+		val, errs = t.source.NormalizeWithErrors(v)
 	} else {
 		val = v
 	}
 
+	// Record is rejected by fail policy
+	if err := getFailure(errs); err != nil {
+		return nil, err
+	}
+
 	return val, nil
 }
 
@@ -102,7 +109,12 @@ func (t *Transformer) Transform(env map[string]interface{}, input map[string]int
 
 	var data map[string]interface{} = input
 	if t.source != nil {
-		data = t.source.Normalize(input)
+
+		var errs []*ConversionError
+		data, errs = t.source.NormalizeWithErrors(input)
+		if err := getFailure(errs); err != nil {
+			return nil, err
+		}
 	}
 
 	t.runtime.SetEnv(env)
@@ -0,0 +1,84 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	BINARY_ENCODING_RAW       = "raw"
+	BINARY_ENCODING_BASE64    = "base64"
+	BINARY_ENCODING_BASE64URL = "base64url"
+	BINARY_ENCODING_HEX       = "hex"
+)
+
+type Binary struct {
+	Encoding string
+}
+
+func NewBinary() *Binary {
+	return &Binary{
+		Encoding: BINARY_ENCODING_RAW,
+	}
+}
+
+func (b *Binary) Parse(data interface{}) error {
+
+	props := data.(map[string]interface{})
+	v, ok := props["encoding"]
+	if !ok {
+		return nil
+	}
+
+	encoding, _ := v.(string)
+	switch encoding {
+	case BINARY_ENCODING_RAW,
+		BINARY_ENCODING_BASE64,
+		BINARY_ENCODING_BASE64URL,
+		BINARY_ENCODING_HEX:
+		b.Encoding = encoding
+		return nil
+	}
+
+	return fmt.Errorf("Unsupported binary encoding: %v", v)
+}
+
+// Decode converts string to bytes with encoding. Padding of base64 is optional.
+func (b *Binary) Decode(s string) ([]byte, error) {
+
+	var result []byte
+	var err error
+	switch b.Encoding {
+	case BINARY_ENCODING_BASE64:
+		result, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(s, "="))
+	case BINARY_ENCODING_BASE64URL:
+		result, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	case BINARY_ENCODING_HEX:
+		result, err = hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X"))
+	default:
+		return []byte(s), nil
+	}
+
+	if err != nil {
+		return nil, ErrUnparsableValue
+	}
+
+	return result, nil
+}
+
+// Encode converts bytes to string with encoding
+func (b *Binary) Encode(data []byte) string {
+
+	switch b.Encoding {
+	case BINARY_ENCODING_BASE64:
+		return base64.StdEncoding.EncodeToString(data)
+	case BINARY_ENCODING_BASE64URL:
+		return base64.URLEncoding.EncodeToString(data)
+	case BINARY_ENCODING_HEX:
+		return hex.EncodeToString(data)
+	}
+
+	return string(data)
+}
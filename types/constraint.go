@@ -1,7 +1,344 @@
 package types
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+)
 
 var (
-	ErrEmptyValue = errors.New("empty value")
+	ErrEmptyValue      = errors.New("empty value")
+	ErrUnparsableValue = errors.New("unparsable value")
+	ErrOutOfRange      = errors.New("value out of range")
+	ErrInvalidLength   = errors.New("invalid length")
+	ErrPatternMismatch = errors.New("value does not match pattern")
+	ErrNotInEnum       = errors.New("value is not in enum")
+	ErrNotMultipleOf   = errors.New("value is not a multiple")
+	ErrInvalidItems    = errors.New("invalid number of items")
 )
+
+type Constraint struct {
+	Minimum          *float64
+	Maximum          *float64
+	ExclusiveMinimum *float64
+	ExclusiveMaximum *float64
+	MultipleOf       *float64
+	MinLength        *int
+	MaxLength        *int
+	MinItems         *int
+	MaxItems         *int
+	Pattern          *regexp.Regexp
+	Enum             []interface{}
+}
+
+func NewConstraint() *Constraint {
+	return &Constraint{}
+}
+
+func parseNumberProp(props map[string]interface{}, key string) (*float64, error) {
+
+	v, ok := props[key]
+	if !ok {
+		return nil, nil
+	}
+
+	rv := reflect.ValueOf(v)
+
+	var n float64
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n = float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		n = rv.Float()
+	default:
+		return nil, fmt.Errorf("Invalid %s constraint: %v", key, v)
+	}
+
+	return &n, nil
+}
+
+func parseLengthProp(props map[string]interface{}, key string) (*int, error) {
+
+	n, err := parseNumberProp(props, key)
+	if err != nil || n == nil {
+		return nil, err
+	}
+
+	if *n < 0 || *n != math.Trunc(*n) {
+		return nil, fmt.Errorf("Invalid %s constraint: %v", key, *n)
+	}
+
+	l := int(*n)
+
+	return &l, nil
+}
+
+func (c *Constraint) Parse(data interface{}) error {
+
+	props := data.(map[string]interface{})
+
+	var err error
+	if c.Minimum, err = parseNumberProp(props, "minimum"); err != nil {
+		return err
+	}
+
+	if c.Maximum, err = parseNumberProp(props, "maximum"); err != nil {
+		return err
+	}
+
+	if c.ExclusiveMinimum, err = parseNumberProp(props, "exclusiveMinimum"); err != nil {
+		return err
+	}
+
+	if c.ExclusiveMaximum, err = parseNumberProp(props, "exclusiveMaximum"); err != nil {
+		return err
+	}
+
+	if c.MultipleOf, err = parseNumberProp(props, "multipleOf"); err != nil {
+		return err
+	}
+
+	if c.MultipleOf != nil && *c.MultipleOf <= 0 {
+		return fmt.Errorf("Invalid multipleOf constraint: %v", *c.MultipleOf)
+	}
+
+	if c.MinLength, err = parseLengthProp(props, "minLength"); err != nil {
+		return err
+	}
+
+	if c.MaxLength, err = parseLengthProp(props, "maxLength"); err != nil {
+		return err
+	}
+
+	if c.MinItems, err = parseLengthProp(props, "minItems"); err != nil {
+		return err
+	}
+
+	if c.MaxItems, err = parseLengthProp(props, "maxItems"); err != nil {
+		return err
+	}
+
+	if v, ok := props["pattern"]; ok {
+
+		pattern, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("Invalid pattern constraint: %v", v)
+		}
+
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return err
+		}
+
+		c.Pattern = re
+	}
+
+	if v, ok := props["enum"]; ok {
+
+		enum, ok := v.([]interface{})
+		if !ok {
+			return fmt.Errorf("Invalid enum constraint: %v", v)
+		}
+
+		c.Enum = enum
+	}
+
+	return nil
+}
+
+// IsEmpty returns true if no constraint was specified
+func (c *Constraint) IsEmpty() bool {
+	return c.Minimum == nil && c.Maximum == nil &&
+		c.ExclusiveMinimum == nil && c.ExclusiveMaximum == nil && c.MultipleOf == nil &&
+		c.MinLength == nil && c.MaxLength == nil &&
+		c.MinItems == nil && c.MaxItems == nil &&
+		c.Pattern == nil && c.Enum == nil
+}
+
+func (c *Constraint) CheckNumber(n float64) error {
+
+	if c.Minimum != nil && n < *c.Minimum {
+		return ErrOutOfRange
+	}
+
+	if c.Maximum != nil && n > *c.Maximum {
+		return ErrOutOfRange
+	}
+
+	if c.ExclusiveMinimum != nil && n <= *c.ExclusiveMinimum {
+		return ErrOutOfRange
+	}
+
+	if c.ExclusiveMaximum != nil && n >= *c.ExclusiveMaximum {
+		return ErrOutOfRange
+	}
+
+	if c.MultipleOf != nil {
+		q := n / *c.MultipleOf
+		if math.Abs(q-math.Round(q)) > 1e-9 {
+			return ErrNotMultipleOf
+		}
+	}
+
+	return nil
+}
+
+func (c *Constraint) CheckLength(l int) error {
+
+	if c.MinLength != nil && l < *c.MinLength {
+		return ErrInvalidLength
+	}
+
+	if c.MaxLength != nil && l > *c.MaxLength {
+		return ErrInvalidLength
+	}
+
+	return nil
+}
+
+func (c *Constraint) CheckString(s string) error {
+
+	err := c.CheckLength(utf8.RuneCountInString(s))
+	if err != nil {
+		return err
+	}
+
+	if c.Pattern != nil && !c.Pattern.MatchString(s) {
+		return ErrPatternMismatch
+	}
+
+	return nil
+}
+
+func (c *Constraint) CheckItems(n int) error {
+
+	if c.MinItems != nil && n < *c.MinItems {
+		return ErrInvalidItems
+	}
+
+	if c.MaxItems != nil && n > *c.MaxItems {
+		return ErrInvalidItems
+	}
+
+	return nil
+}
+
+// getStandardNumber converts sized numbers to int64, uint64 or float64
+func getStandardNumber(data interface{}) interface{} {
+
+	switch d := data.(type) {
+	case int8:
+		return int64(d)
+	case int16:
+		return int64(d)
+	case int32:
+		return int64(d)
+	case uint8:
+		return uint64(d)
+	case uint16:
+		return uint64(d)
+	case uint32:
+		return uint64(d)
+	case float32:
+		// Use shortest representation to prevent getting noise digits from float32
+		f, _ := strconv.ParseFloat(strconv.FormatFloat(float64(d), 'g', -1, 32), 64)
+		return f
+	}
+
+	return data
+}
+
+func toFloat64(data interface{}) (float64, bool) {
+
+	switch d := data.(type) {
+	case int64:
+		return float64(d), true
+	case uint64:
+		return float64(d), true
+	case float64:
+		return d, true
+	case DecimalValue:
+		return d.Float64(), true
+	}
+
+	rv := reflect.ValueOf(data)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+
+	return 0, false
+}
+
+func (c *Constraint) CheckEnum(data interface{}) error {
+
+	if c.Enum == nil {
+		return nil
+	}
+
+	n, isNumber := toFloat64(data)
+
+	for _, e := range c.Enum {
+
+		if isNumber {
+			if en, ok := toFloat64(e); ok && en == n {
+				return nil
+			}
+
+			continue
+		}
+
+		if e == data {
+			return nil
+		}
+	}
+
+	return ErrNotInEnum
+}
+
+// Check validates normalized value with constraints
+func (c *Constraint) Check(data interface{}) error {
+
+	if data == nil {
+		return nil
+	}
+
+	data = getStandardNumber(data)
+
+	switch d := data.(type) {
+	case int64, uint64, float64, DecimalValue:
+		n, _ := toFloat64(d)
+		err := c.CheckNumber(n)
+		if err != nil {
+			return err
+		}
+	case string:
+		err := c.CheckString(d)
+		if err != nil {
+			return err
+		}
+	case []byte:
+		err := c.CheckLength(len(d))
+		if err != nil {
+			return err
+		}
+
+		return nil
+	case []interface{}:
+		return c.CheckItems(len(d))
+	case map[string]interface{}:
+		return nil
+	}
+
+	return c.CheckEnum(data)
+}
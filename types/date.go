@@ -0,0 +1,107 @@
+package types
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	// Numbers less than this limit are treated as days since epoch, otherwise unix timestamp
+	maxEpochDays = 1000000
+)
+
+var DateLayouts = []string{
+	"2006-01-02",
+	"2006/01/02",
+	"20060102",
+	"2006.01.02",
+	"Jan 2, 2006",
+	"2 Jan 2006",
+}
+
+// Date represents a calendar date without time and time zone
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+func NewDateFromTime(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{
+		Year:  year,
+		Month: month,
+		Day:   day,
+	}
+}
+
+func NewDateFromEpochDays(days int64) Date {
+	return NewDateFromTime(time.Unix(days*86400, 0).UTC())
+}
+
+// Time returns midnight of the date in UTC
+func (d Date) Time() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC)
+}
+
+// EpochDays returns number of days since 1970-01-01
+func (d Date) EpochDays() int64 {
+	return int64(math.Floor(float64(d.Time().Unix()) / 86400))
+}
+
+func (d Date) String() string {
+	return d.Time().Format("2006-01-02")
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+func (t *Time) getDateByNumber(n int64) Date {
+
+	if n > -maxEpochDays && n < maxEpochDays {
+		return NewDateFromEpochDays(n)
+	}
+
+	return NewDateFromTime(t.getValueByPrecision(n).UTC())
+}
+
+// GetDate converts value to date. Time part of timestamp will be ignored.
+func (t *Time) GetDate(data interface{}) (Date, error) {
+
+	switch d := data.(type) {
+	case Date:
+		return d, nil
+	case time.Time:
+		return NewDateFromTime(d), nil
+	case int64:
+		return t.getDateByNumber(d), nil
+	case uint64:
+		return t.getDateByNumber(int64(d)), nil
+	case float64:
+		return t.getDateByNumber(int64(d)), nil
+	case string:
+
+		str := strings.TrimSpace(d)
+		if len(str) == 0 {
+			return Date{}, ErrEmptyValue
+		}
+
+		for _, layout := range DateLayouts {
+			if v, err := time.Parse(layout, str); err == nil {
+				return NewDateFromTime(v), nil
+			}
+		}
+
+		// Timestamp
+		v, err := t.GetValue(str)
+		if err != nil {
+			return Date{}, err
+		}
+
+		return NewDateFromTime(v), nil
+	}
+
+	return Date{}, ErrUnparsableValue
+}
@@ -0,0 +1,324 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var bigTen = big.NewInt(10)
+
+// MaxDecimalScale limits scale of parsed decimal, so exponent cannot produce numbers with huge amount of digits
+const MaxDecimalScale = 1000
+
+// DecimalValue represents an exact decimal number which equals Unscaled * 10^-Scale
+type DecimalValue struct {
+	Unscaled *big.Int
+	Scale    int32
+}
+
+func NewDecimalValue(unscaled *big.Int, scale int32) DecimalValue {
+	return DecimalValue{
+		Unscaled: unscaled,
+		Scale:    scale,
+	}
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// ParseDecimalValue parses decimal string such as "-123.45" or "1.2e3"
+func ParseDecimalValue(s string) (DecimalValue, error) {
+
+	str := strings.TrimSpace(s)
+	if len(str) == 0 {
+		return DecimalValue{}, ErrEmptyValue
+	}
+
+	// Exponent
+	exp := int64(0)
+	if idx := strings.IndexAny(str, "eE"); idx != -1 {
+		e, err := strconv.ParseInt(str[idx+1:], 10, 32)
+		if err != nil {
+			return DecimalValue{}, ErrUnparsableValue
+		}
+
+		exp = e
+		str = str[:idx]
+	}
+
+	negative := false
+	switch {
+	case strings.HasPrefix(str, "-"):
+		negative = true
+		str = str[1:]
+	case strings.HasPrefix(str, "+"):
+		str = str[1:]
+	}
+
+	intPart := str
+	fracPart := ""
+	if idx := strings.IndexByte(str, '.'); idx != -1 {
+		intPart = str[:idx]
+		fracPart = str[idx+1:]
+	}
+
+	digits := intPart + fracPart
+	if len(digits) == 0 {
+		return DecimalValue{}, ErrUnparsableValue
+	}
+
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return DecimalValue{}, ErrUnparsableValue
+		}
+	}
+
+	unscaled, _ := new(big.Int).SetString(digits, 10)
+	if negative {
+		unscaled.Neg(unscaled)
+	}
+
+	scale := int64(len(fracPart)) - exp
+	if scale > MaxDecimalScale || scale < -MaxDecimalScale {
+		return DecimalValue{}, ErrOutOfRange
+	}
+
+	if scale < 0 {
+		unscaled.Mul(unscaled, pow10(int32(-scale)))
+		scale = 0
+	}
+
+	return NewDecimalValue(unscaled, int32(scale)), nil
+}
+
+// DecimalValueFromBytes creates decimal from big-endian two's complement representation of unscaled value
+func DecimalValueFromBytes(b []byte, scale int32) DecimalValue {
+
+	unscaled := new(big.Int).SetBytes(b)
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		unscaled.Sub(unscaled, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+	}
+
+	return NewDecimalValue(unscaled, scale)
+}
+
+func (d DecimalValue) unscaled() *big.Int {
+
+	if d.Unscaled == nil {
+		return new(big.Int)
+	}
+
+	return d.Unscaled
+}
+
+func (d DecimalValue) String() string {
+
+	unscaled := d.unscaled()
+	digits := new(big.Int).Abs(unscaled).String()
+
+	if d.Scale > 0 {
+		scale := int(d.Scale)
+		if len(digits) <= scale {
+			digits = strings.Repeat("0", scale-len(digits)+1) + digits
+		}
+
+		digits = digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+	} else if d.Scale < 0 {
+		digits = new(big.Int).Mul(new(big.Int).Abs(unscaled), pow10(-d.Scale)).String()
+	}
+
+	if unscaled.Sign() < 0 {
+		return "-" + digits
+	}
+
+	return digits
+}
+
+func (d DecimalValue) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d DecimalValue) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// Int returns integer part of decimal
+func (d DecimalValue) Int() *big.Int {
+
+	if d.Scale <= 0 {
+		return new(big.Int).Mul(d.unscaled(), pow10(-d.Scale))
+	}
+
+	return new(big.Int).Quo(d.unscaled(), pow10(d.Scale))
+}
+
+func (d DecimalValue) Sign() int {
+	return d.unscaled().Sign()
+}
+
+// Digits returns number of digits of unscaled value
+func (d DecimalValue) Digits() int {
+
+	unscaled := d.unscaled()
+	if unscaled.Sign() == 0 {
+		return 1
+	}
+
+	return len(new(big.Int).Abs(unscaled).String())
+}
+
+// Rescale returns decimal with specific scale. Value will be rounded half away from zero.
+func (d DecimalValue) Rescale(scale int32) DecimalValue {
+
+	unscaled := d.unscaled()
+
+	if scale >= d.Scale {
+		return NewDecimalValue(new(big.Int).Mul(unscaled, pow10(scale-d.Scale)), scale)
+	}
+
+	divisor := pow10(d.Scale - scale)
+	q, r := new(big.Int).QuoRem(new(big.Int).Abs(unscaled), divisor, new(big.Int))
+
+	if r.Lsh(r, 1).Cmp(divisor) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+
+	if unscaled.Sign() < 0 {
+		q.Neg(q)
+	}
+
+	return NewDecimalValue(q, scale)
+}
+
+// Bytes returns big-endian two's complement representation of unscaled value
+func (d DecimalValue) Bytes() []byte {
+
+	unscaled := d.unscaled()
+
+	if unscaled.Sign() >= 0 {
+		b := make([]byte, unscaled.BitLen()/8+1)
+		return unscaled.FillBytes(b)
+	}
+
+	size := new(big.Int).Not(unscaled).BitLen()/8 + 1
+	v := new(big.Int).Add(unscaled, new(big.Int).Lsh(big.NewInt(1), uint(size*8)))
+
+	return v.FillBytes(make([]byte, size))
+}
+
+type Decimal struct {
+	Precision int32
+	Scale     int32
+}
+
+func NewDecimal() *Decimal {
+	return &Decimal{
+		Precision: 0,
+		Scale:     -1,
+	}
+}
+
+func parseIntegerProp(props map[string]interface{}, key string) (int32, bool, error) {
+
+	n, err := parseNumberProp(props, key)
+	if err != nil || n == nil {
+		return 0, false, err
+	}
+
+	if *n < 0 || *n != math.Trunc(*n) || *n > math.MaxInt32 {
+		return 0, false, fmt.Errorf("Invalid %s: %v", key, *n)
+	}
+
+	return int32(*n), true, nil
+}
+
+func (d *Decimal) Parse(data interface{}) error {
+
+	props := data.(map[string]interface{})
+
+	precision, ok, err := parseIntegerProp(props, "precision")
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		d.Precision = precision
+	}
+
+	scale, ok, err := parseIntegerProp(props, "scale")
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		d.Scale = scale
+	}
+
+	if d.Precision > 0 && d.Scale > d.Precision {
+		return fmt.Errorf("Invalid scale: %d is greater than precision %d", d.Scale, d.Precision)
+	}
+
+	return nil
+}
+
+func (d *Decimal) GetValue(data interface{}) (DecimalValue, error) {
+
+	var value DecimalValue
+
+	switch v := data.(type) {
+	case DecimalValue:
+		value = v
+	case int64:
+		value = NewDecimalValue(big.NewInt(v), 0)
+	case uint64:
+		value = NewDecimalValue(new(big.Int).SetUint64(v), 0)
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return DecimalValue{}, ErrUnparsableValue
+		}
+
+		dv, err := ParseDecimalValue(strconv.FormatFloat(v, 'f', -1, 64))
+		if err != nil {
+			return DecimalValue{}, err
+		}
+
+		value = dv
+	case string:
+		dv, err := ParseDecimalValue(v)
+		if err != nil {
+			return DecimalValue{}, err
+		}
+
+		value = dv
+	case []byte:
+		scale := d.Scale
+		if scale < 0 {
+			scale = 0
+		}
+
+		value = DecimalValueFromBytes(v, scale)
+	case bool:
+		if v {
+			value = NewDecimalValue(big.NewInt(1), 0)
+		} else {
+			value = NewDecimalValue(big.NewInt(0), 0)
+		}
+	default:
+		return DecimalValue{}, ErrUnparsableValue
+	}
+
+	if d.Scale >= 0 && value.Scale != d.Scale {
+		value = value.Rescale(d.Scale)
+	}
+
+	if d.Precision > 0 && value.Digits() > int(d.Precision) {
+		return value, ErrOutOfRange
+	}
+
+	return value, nil
+}
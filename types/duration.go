@@ -0,0 +1,175 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var DurationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+}
+
+// Designators of ISO 8601 duration. Year and month are approximated as 365 and 30 days.
+var isoDateDesignators = map[byte]time.Duration{
+	'Y': 365 * Day,
+	'M': 30 * Day,
+	'W': 7 * Day,
+	'D': Day,
+}
+
+var isoTimeDesignators = map[byte]time.Duration{
+	'H': time.Hour,
+	'M': time.Minute,
+	'S': time.Second,
+}
+
+type Duration struct {
+	Unit time.Duration
+}
+
+func NewDuration() *Duration {
+	return &Duration{
+		Unit: time.Second,
+	}
+}
+
+func (d *Duration) Parse(data interface{}) error {
+
+	props := data.(map[string]interface{})
+	if v, ok := props["unit"]; ok {
+
+		name, _ := v.(string)
+		unit, ok := DurationUnits[name]
+		if !ok {
+			return fmt.Errorf("Unsupported duration unit: %v", v)
+		}
+
+		d.Unit = unit
+	}
+
+	return nil
+}
+
+func (d *Duration) getValueByUnit(n float64) (time.Duration, error) {
+
+	v := n * float64(d.Unit)
+	if math.IsNaN(v) || v > math.MaxInt64 || v < math.MinInt64 {
+		return 0, ErrOutOfRange
+	}
+
+	return time.Duration(v), nil
+}
+
+// GetValue converts value to duration. Numbers are treated as amount of unit.
+func (d *Duration) GetValue(data interface{}) (time.Duration, error) {
+
+	switch v := data.(type) {
+	case time.Duration:
+		return v, nil
+	case int64:
+		return d.getValueByUnit(float64(v))
+	case uint64:
+		return d.getValueByUnit(float64(v))
+	case float64:
+		return d.getValueByUnit(v)
+	case string:
+
+		str := strings.TrimSpace(v)
+		if len(str) == 0 {
+			return 0, ErrEmptyValue
+		}
+
+		if n, err := strconv.ParseFloat(str, 64); err == nil {
+			return d.getValueByUnit(n)
+		}
+
+		if strings.HasPrefix(str, "P") || strings.HasPrefix(str, "-P") || strings.HasPrefix(str, "+P") {
+			return ParseISODuration(str)
+		}
+
+		result, err := time.ParseDuration(str)
+		if err != nil {
+			return 0, ErrUnparsableValue
+		}
+
+		return result, nil
+	}
+
+	return 0, ErrUnparsableValue
+}
+
+// ParseISODuration parses ISO 8601 duration such as "P1DT2H30M" or "PT0.5S"
+func ParseISODuration(s string) (time.Duration, error) {
+
+	str := s
+	negative := false
+	switch str[0] {
+	case '-':
+		negative = true
+		str = str[1:]
+	case '+':
+		str = str[1:]
+	}
+
+	if len(str) < 2 || str[0] != 'P' {
+		return 0, ErrUnparsableValue
+	}
+
+	str = str[1:]
+
+	var result float64
+	designators := isoDateDesignators
+	for len(str) > 0 {
+
+		if str[0] == 'T' {
+
+			// Time part cannot be empty
+			if len(str) == 1 {
+				return 0, ErrUnparsableValue
+			}
+
+			designators = isoTimeDesignators
+			str = str[1:]
+			continue
+		}
+
+		// Find designator
+		idx := strings.IndexFunc(str, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.' && r != ','
+		})
+		if idx <= 0 {
+			return 0, ErrUnparsableValue
+		}
+
+		unit, ok := designators[str[idx]]
+		if !ok {
+			return 0, ErrUnparsableValue
+		}
+
+		n, err := strconv.ParseFloat(strings.Replace(str[:idx], ",", ".", 1), 64)
+		if err != nil {
+			return 0, ErrUnparsableValue
+		}
+
+		result += n * float64(unit)
+		str = str[idx+1:]
+	}
+
+	if result > math.MaxInt64 {
+		return 0, ErrOutOfRange
+	}
+
+	if negative {
+		result = -result
+	}
+
+	return time.Duration(result), nil
+}
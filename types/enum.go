@@ -0,0 +1,169 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// EnumValue represents a symbol of enum with its numeric code
+type EnumValue struct {
+	Symbol string
+	Code   int64
+}
+
+func (e EnumValue) String() string {
+	return e.Symbol
+}
+
+func (e EnumValue) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(e.Symbol)), nil
+}
+
+type Enum struct {
+	Symbols []string
+	Codes   []int64
+}
+
+func NewEnum() *Enum {
+	return &Enum{
+		Symbols: make([]string, 0),
+		Codes:   make([]int64, 0),
+	}
+}
+
+func (e *Enum) Parse(data interface{}) error {
+
+	props := data.(map[string]interface{})
+
+	symbols, ok := props["symbols"].([]interface{})
+	if !ok || len(symbols) == 0 {
+		return fmt.Errorf("Enum requires symbols")
+	}
+
+	e.Symbols = make([]string, len(symbols))
+	for i, v := range symbols {
+
+		symbol, ok := v.(string)
+		if !ok || len(symbol) == 0 {
+			return fmt.Errorf("Invalid enum symbol: %v", v)
+		}
+
+		if _, ok := e.indexOfSymbol(symbol, i); ok {
+			return fmt.Errorf("Duplicate enum symbol: %s", symbol)
+		}
+
+		e.Symbols[i] = symbol
+	}
+
+	// Codes are indexes of symbols by default
+	e.Codes = make([]int64, len(symbols))
+	for i := range e.Codes {
+		e.Codes[i] = int64(i)
+	}
+
+	v, ok := props["codes"]
+	if !ok {
+		return nil
+	}
+
+	codes, ok := v.([]interface{})
+	if !ok || len(codes) != len(symbols) {
+		return fmt.Errorf("Enum codes should match symbols")
+	}
+
+	for i, c := range codes {
+
+		n, ok := toFloat64(c)
+		if !ok || n != math.Trunc(n) {
+			return fmt.Errorf("Invalid enum code: %v", c)
+		}
+
+		if _, ok := e.indexOfCode(int64(n), i); ok {
+			return fmt.Errorf("Duplicate enum code: %v", c)
+		}
+
+		e.Codes[i] = int64(n)
+	}
+
+	return nil
+}
+
+// indexOfSymbol finds symbol case-insensitively in first n symbols
+func (e *Enum) indexOfSymbol(symbol string, n int) (int, bool) {
+
+	for i := 0; i < n; i++ {
+		if strings.EqualFold(e.Symbols[i], symbol) {
+			return i, true
+		}
+	}
+
+	return -1, false
+}
+
+func (e *Enum) indexOfCode(code int64, n int) (int, bool) {
+
+	for i := 0; i < n; i++ {
+		if e.Codes[i] == code {
+			return i, true
+		}
+	}
+
+	return -1, false
+}
+
+func (e *Enum) getValueByIndex(idx int) EnumValue {
+	return EnumValue{
+		Symbol: e.Symbols[idx],
+		Code:   e.Codes[idx],
+	}
+}
+
+func (e *Enum) getValueByCode(n float64) (EnumValue, error) {
+
+	if n != math.Trunc(n) {
+		return EnumValue{}, ErrNotInEnum
+	}
+
+	idx, ok := e.indexOfCode(int64(n), len(e.Codes))
+	if !ok {
+		return EnumValue{}, ErrNotInEnum
+	}
+
+	return e.getValueByIndex(idx), nil
+}
+
+// GetValue converts symbol or code to enum value. Symbols are case-insensitive.
+func (e *Enum) GetValue(data interface{}) (EnumValue, error) {
+
+	switch d := data.(type) {
+	case EnumValue:
+		return e.GetValue(d.Symbol)
+	case int64:
+		return e.getValueByCode(float64(d))
+	case uint64:
+		return e.getValueByCode(float64(d))
+	case float64:
+		return e.getValueByCode(d)
+	case string:
+
+		str := strings.TrimSpace(d)
+		if len(str) == 0 {
+			return EnumValue{}, ErrEmptyValue
+		}
+
+		if idx, ok := e.indexOfSymbol(str, len(e.Symbols)); ok {
+			return e.getValueByIndex(idx), nil
+		}
+
+		// Code in string
+		if n, err := strconv.ParseInt(str, 10, 64); err == nil {
+			return e.getValueByCode(float64(n))
+		}
+
+		return EnumValue{}, ErrNotInEnum
+	}
+
+	return EnumValue{}, ErrUnparsableValue
+}
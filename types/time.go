@@ -4,8 +4,14 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	// Embedded time zone database to load IANA time zones in any environment
+	_ "time/tzdata"
 )
 
+// Layout for timestamps without time zone
+const localTimeLayout = "2006-01-02T15:04:05.999999999"
+
 type TimePrecision int32
 
 const (
@@ -25,15 +31,155 @@ var TimePrecisions = map[string]TimePrecision{
 	"microsecond": TIME_PRECISION_MICROSECOND,
 }
 
+func (p TimePrecision) String() string {
+
+	for name, precision := range TimePrecisions {
+		if precision == p {
+			return name
+		}
+	}
+
+	return "unknown"
+}
+
+// Named layouts which can be used as format
+var TimeLayouts = map[string]string{
+	"ANSIC":       time.ANSIC,
+	"UnixDate":    time.UnixDate,
+	"RubyDate":    time.RubyDate,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+	"DateTime":    time.DateTime,
+	"DateOnly":    time.DateOnly,
+	"TimeOnly":    time.TimeOnly,
+}
+
+var strftimeDirectives = map[byte]string{
+	'Y': "2006",
+	'y': "06",
+	'm': "01",
+	'd': "02",
+	'e': "_2",
+	'j': "002",
+	'H': "15",
+	'I': "03",
+	'l': "3",
+	'M': "04",
+	'S': "05",
+	'f': "000000",
+	'L': "000",
+	'p': "PM",
+	'b': "Jan",
+	'h': "Jan",
+	'B': "January",
+	'a': "Mon",
+	'A': "Monday",
+	'z': "-0700",
+	'Z': "MST",
+	'F': "2006-01-02",
+	'T': "15:04:05",
+	'D': "01/02/06",
+	'R': "15:04",
+	'%': "%",
+}
+
 type Time struct {
 	Precision TimePrecision
-	Format    string
+
+	// Format is the layout for output, and Layouts are used for parsing in order
+	Format  string
+	Layouts []string
+
+	// Location is used for parsing timestamps without time zone and output. DefaultLocation is inherited from schema.
+	Location        *time.Location
+	DefaultLocation *time.Location
 }
 
 func NewTime() *Time {
 	return &Time{}
 }
 
+// ConvertStrftime converts strftime-style pattern such as "%Y-%m-%d" to layout of Go
+func ConvertStrftime(pattern string) (string, error) {
+
+	var layout strings.Builder
+	for i := 0; i < len(pattern); i++ {
+
+		if pattern[i] != '%' {
+			layout.WriteByte(pattern[i])
+			continue
+		}
+
+		if i+1 >= len(pattern) {
+			return "", fmt.Errorf("Invalid time format: %s", pattern)
+		}
+
+		i++
+		directive, ok := strftimeDirectives[pattern[i]]
+		if !ok {
+			return "", fmt.Errorf("Unsupported time format directive: %%%c", pattern[i])
+		}
+
+		layout.WriteString(directive)
+	}
+
+	return layout.String(), nil
+}
+
+func parseLayout(format string) (string, error) {
+
+	if layout, ok := TimeLayouts[format]; ok {
+		return layout, nil
+	}
+
+	if strings.Contains(format, "%") {
+		return ConvertStrftime(format)
+	}
+
+	return format, nil
+}
+
+func (t *Time) parseFormat(v interface{}) error {
+
+	var formats []interface{}
+	switch d := v.(type) {
+	case string:
+		formats = []interface{}{d}
+	case []interface{}:
+		formats = d
+	}
+
+	if len(formats) == 0 {
+		return fmt.Errorf("Invalid time format: %v", v)
+	}
+
+	t.Layouts = make([]string, len(formats))
+	for i, f := range formats {
+
+		format, ok := f.(string)
+		if !ok || len(format) == 0 {
+			return fmt.Errorf("Invalid time format: %v", f)
+		}
+
+		layout, err := parseLayout(format)
+		if err != nil {
+			return err
+		}
+
+		t.Layouts[i] = layout
+	}
+
+	t.Format = t.Layouts[0]
+
+	return nil
+}
+
 func (t *Time) Parse(data interface{}) error {
 
 	props := data.(map[string]interface{})
@@ -47,9 +193,50 @@ func (t *Time) Parse(data interface{}) error {
 		t.Precision = p
 	}
 
+	if v, ok := props["timezone"]; ok {
+
+		name, _ := v.(string)
+		loc, err := time.LoadLocation(name)
+		if err != nil || len(name) == 0 {
+			return fmt.Errorf("Unsupported timezone: %v", v)
+		}
+
+		t.Location = loc
+	}
+
+	if v, ok := props["format"]; ok {
+		return t.parseFormat(v)
+	}
+
 	return nil
 }
 
+// GetLocation returns time zone for timestamps without time zone. Nil will be returned if it is not specified.
+func (t *Time) GetLocation() *time.Location {
+
+	if t.Location != nil {
+		return t.Location
+	}
+
+	return t.DefaultLocation
+}
+
+// FormatValue converts time to string with output format and time zone. RFC3339 in UTC is used by default.
+func (t *Time) FormatValue(v time.Time) string {
+
+	if t.Location != nil {
+		v = v.In(t.Location)
+	} else {
+		v = v.UTC()
+	}
+
+	if len(t.Format) == 0 {
+		return v.Format(time.RFC3339Nano)
+	}
+
+	return v.Format(t.Format)
+}
+
 func (t *Time) getValueByPrecision(d int64) time.Time {
 
 	switch t.Precision {
@@ -76,6 +263,10 @@ func (t *Time) GetValue(data interface{}) (time.Time, error) {
 	switch d := data.(type) {
 	case time.Time:
 		return d, nil
+	case Date:
+		return d.Time(), nil
+	case TimeOfDay:
+		return d.Time(), nil
 	case int64:
 		return t.getValueByPrecision(d), nil
 	case uint64:
@@ -86,17 +277,44 @@ func (t *Time) GetValue(data interface{}) (time.Time, error) {
 			return time.Unix(0, 0), ErrEmptyValue
 		}
 
+		loc := t.GetLocation()
+
+		// Attempt to parse with specific layouts
+		for _, layout := range t.Layouts {
+
+			if loc != nil {
+				if v, err := time.ParseInLocation(layout, d, loc); err == nil {
+					return v, nil
+				}
+
+				continue
+			}
+
+			if v, err := time.Parse(layout, d); err == nil {
+				return v, nil
+			}
+		}
+
 		t, err := time.Parse(time.RFC3339Nano, d)
 		if err != nil {
 
 			str := strings.Replace(d, " ", "T", 1)
 
 			if d[len(d)-1:] != "Z" {
-				t, _ = time.Parse(time.RFC3339Nano, str+"Z")
+
+				// Timestamp without time zone
+				if loc != nil {
+					t, err = time.ParseInLocation(localTimeLayout, str, loc)
+				} else {
+					t, err = time.Parse(time.RFC3339Nano, str+"Z")
+				}
 			} else {
-				t, _ = time.Parse(time.RFC3339Nano, str)
+				t, err = time.Parse(time.RFC3339Nano, str)
 			}
 
+			if err != nil {
+				return t, ErrUnparsableValue
+			}
 		}
 
 		return t, nil
@@ -0,0 +1,113 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const Day = 24 * time.Hour
+
+var TimeOfDayLayouts = []string{
+	"15:04:05",
+	"15:04",
+	"150405",
+	"3:04:05PM",
+	"3:04:05 PM",
+	"3:04PM",
+	"3:04 PM",
+}
+
+// TimeOfDay represents wall clock time as duration since midnight
+type TimeOfDay time.Duration
+
+func NewTimeOfDayFromTime(t time.Time) TimeOfDay {
+	hour, min, sec := t.Clock()
+	return TimeOfDay(time.Duration(hour)*time.Hour +
+		time.Duration(min)*time.Minute +
+		time.Duration(sec)*time.Second +
+		time.Duration(t.Nanosecond()))
+}
+
+func (tod TimeOfDay) Duration() time.Duration {
+	return time.Duration(tod)
+}
+
+// Time returns the time of day on zero date in UTC
+func (tod TimeOfDay) Time() time.Time {
+	return time.Time{}.Add(tod.Duration())
+}
+
+func (tod TimeOfDay) String() string {
+
+	d := tod.Duration()
+	str := fmt.Sprintf("%02d:%02d:%02d", d/time.Hour, d%time.Hour/time.Minute, d%time.Minute/time.Second)
+
+	nsec := d % time.Second
+	if nsec == 0 {
+		return str
+	}
+
+	return str + strings.TrimRight(fmt.Sprintf(".%09d", nsec), "0")
+}
+
+func (tod TimeOfDay) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + tod.String() + `"`), nil
+}
+
+func (t *Time) getTimeOfDayByNumber(n float64) (TimeOfDay, error) {
+
+	unit := time.Second
+	switch t.Precision {
+	case TIME_PRECISION_MILLISECOND:
+		unit = time.Millisecond
+	case TIME_PRECISION_MICROSECOND:
+		unit = time.Microsecond
+	}
+
+	d := time.Duration(n * float64(unit))
+	if d < 0 || d >= Day {
+		return 0, ErrOutOfRange
+	}
+
+	return TimeOfDay(d), nil
+}
+
+// GetTimeOfDay converts value to time of day. Numbers are treated as elapsed time since midnight in unit of precision.
+func (t *Time) GetTimeOfDay(data interface{}) (TimeOfDay, error) {
+
+	switch d := data.(type) {
+	case TimeOfDay:
+		return d, nil
+	case time.Time:
+		return NewTimeOfDayFromTime(d), nil
+	case int64:
+		return t.getTimeOfDayByNumber(float64(d))
+	case uint64:
+		return t.getTimeOfDayByNumber(float64(d))
+	case float64:
+		return t.getTimeOfDayByNumber(d)
+	case string:
+
+		str := strings.TrimSpace(d)
+		if len(str) == 0 {
+			return 0, ErrEmptyValue
+		}
+
+		for _, layout := range TimeOfDayLayouts {
+			if v, err := time.Parse(layout, str); err == nil {
+				return NewTimeOfDayFromTime(v), nil
+			}
+		}
+
+		// Timestamp
+		v, err := t.GetValue(str)
+		if err != nil {
+			return 0, err
+		}
+
+		return NewTimeOfDayFromTime(v), nil
+	}
+
+	return 0, ErrUnparsableValue
+}
@@ -0,0 +1,127 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// UUID represents 16 bytes universally unique identifier
+type UUID [16]byte
+
+// ParseUUID parses UUID from 16 bytes binary, base64, hex or hyphenated string
+func ParseUUID(data interface{}) (UUID, error) {
+
+	switch d := data.(type) {
+	case UUID:
+		return d, nil
+	case []byte:
+		if len(d) == 16 {
+			return newUUIDFromBytes(d), nil
+		}
+
+		return parseUUIDString(string(d))
+	case string:
+		return parseUUIDString(d)
+	}
+
+	return UUID{}, ErrUnparsableValue
+}
+
+// IsCanonicalUUID returns true if string is hyphenated UUID
+func IsCanonicalUUID(s string) bool {
+
+	if len(s) != 36 {
+		return false
+	}
+
+	_, err := parseUUIDString(s)
+
+	return err == nil
+}
+
+func newUUIDFromBytes(b []byte) UUID {
+
+	var u UUID
+	copy(u[:], b)
+
+	return u
+}
+
+func parseUUIDString(s string) (UUID, error) {
+
+	str := strings.TrimSpace(s)
+	if len(str) == 0 {
+		return UUID{}, ErrEmptyValue
+	}
+
+	// Remove URN prefix and braces
+	if len(str) > 9 && strings.EqualFold(str[:9], "urn:uuid:") {
+		str = str[9:]
+	}
+
+	if len(str) == 38 && str[0] == '{' && str[37] == '}' {
+		str = str[1:37]
+	}
+
+	switch len(str) {
+	case 36:
+		if str[8] != '-' || str[13] != '-' || str[18] != '-' || str[23] != '-' {
+			return UUID{}, ErrUnparsableValue
+		}
+
+		str = str[:8] + str[9:13] + str[14:18] + str[19:23] + str[24:]
+		fallthrough
+	case 32:
+		b, err := hex.DecodeString(str)
+		if err != nil {
+			return UUID{}, ErrUnparsableValue
+		}
+
+		return newUUIDFromBytes(b), nil
+	case 22, 24:
+		// Base64 of 16 bytes
+		encoding := base64.StdEncoding
+		if strings.ContainsAny(str, "-_") {
+			encoding = base64.URLEncoding
+		}
+
+		if len(str) == 22 {
+			encoding = encoding.WithPadding(base64.NoPadding)
+		}
+
+		b, err := encoding.DecodeString(str)
+		if err != nil || len(b) != 16 {
+			return UUID{}, ErrUnparsableValue
+		}
+
+		return newUUIDFromBytes(b), nil
+	}
+
+	return UUID{}, ErrUnparsableValue
+}
+
+// String returns canonical form of UUID
+func (u UUID) String() string {
+
+	buf := make([]byte, 36)
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+
+	return string(buf)
+}
+
+func (u UUID) Bytes() []byte {
+	return u[:]
+}
+
+func (u UUID) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + u.String() + `"`), nil
+}
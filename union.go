@@ -0,0 +1,241 @@
+package schemer
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+func extractTypeRawDefinition(v interface{}) (*RawDefinition, error) {
+
+	switch d := v.(type) {
+	case string:
+		return extractRawDefinition(map[string]interface{}{
+			"type": d,
+		})
+	case map[string]interface{}:
+		return extractRawDefinition(d)
+	}
+
+	return nil, ErrInvalidUnionDefinition
+}
+
+func extractUnionRawDefinition(raw *RawDefinition, v map[string]interface{}) error {
+
+	_, discriminated := v["discriminator"].(string)
+
+	switch d := v["types"].(type) {
+	case []interface{}:
+
+		// Alternatives should be selected by discriminator
+		if discriminated || len(d) == 0 {
+			return ErrInvalidUnionDefinition
+		}
+
+		raw.Types = make([]*RawDefinition, len(d))
+		for i, t := range d {
+
+			r, err := extractTypeRawDefinition(t)
+			if err != nil {
+				return err
+			}
+
+			raw.Types[i] = r
+		}
+
+		return nil
+
+	case map[string]interface{}:
+
+		if !discriminated || len(d) == 0 {
+			return ErrInvalidUnionDefinition
+		}
+
+		raw.Mapping = make(map[string]*RawDefinition, len(d))
+		for key, t := range d {
+
+			r, err := extractTypeRawDefinition(t)
+			if err != nil {
+				return err
+			}
+
+			// Discriminator is a field of map, reference will be checked after resolved
+			if len(r.Ref) == 0 && r.Type != TYPE_MAP {
+				return ErrInvalidUnionDefinition
+			}
+
+			raw.Mapping[key] = r
+		}
+
+		return nil
+	}
+
+	return ErrInvalidUnionDefinition
+}
+
+func createUnionDefinition(def *Definition, raw *RawDefinition) error {
+
+	if raw.Mapping == nil {
+
+		def.Types = make([]*Definition, len(raw.Types))
+		for i, r := range raw.Types {
+
+			d, err := createDefinitionFromRawDefinition(r)
+			if err != nil {
+				return err
+			}
+
+			def.Types[i] = d
+		}
+
+		return nil
+	}
+
+	keys := make([]string, 0, len(raw.Mapping))
+	for key := range raw.Mapping {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	def.Discriminator = raw.Props["discriminator"].(string)
+	def.Mapping = make(map[string]*Definition, len(keys))
+	def.Types = make([]*Definition, len(keys))
+	for i, key := range keys {
+
+		d, err := createDefinitionFromRawDefinition(raw.Mapping[key])
+		if err != nil {
+			return err
+		}
+
+		def.Mapping[key] = d
+		def.Types[i] = d
+	}
+
+	return nil
+}
+
+// Resolve returns definition for specific data. Alternative of union will be selected by discriminator or type of data, nil will be returned if nothing matched.
+func (d *Definition) Resolve(data interface{}) *Definition {
+
+	if d.Type != TYPE_UNION {
+		return d
+	}
+
+	if d.Discriminator != "" {
+
+		m, ok := data.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		kind, ok := m[d.Discriminator]
+		if !ok || kind == nil {
+			return nil
+		}
+
+		return d.Mapping[fmt.Sprintf("%v", kind)]
+	}
+
+	if data == nil {
+		return nil
+	}
+
+	v := getStandardValue(data)
+
+	// Prefer alternative with the same type
+	t := inferDefinition(v).Type
+	for _, def := range d.Types {
+		if def.Type == t {
+			return def
+		}
+	}
+
+	for _, def := range d.Types {
+		if isAcceptable(def, v) {
+			return def.Resolve(v)
+		}
+	}
+
+	return nil
+}
+
+func isAcceptable(def *Definition, data interface{}) bool {
+
+	switch def.Type {
+	case TYPE_ANY:
+		return true
+	case TYPE_UNION:
+		return def.Resolve(data) != nil
+	case TYPE_MAP:
+		_, ok := data.(map[string]interface{})
+		return ok
+	case TYPE_ARRAY:
+		if _, ok := data.([]byte); ok {
+			return false
+		}
+
+		kind := reflect.ValueOf(data).Kind()
+		return kind == reflect.Array || kind == reflect.Slice
+	}
+
+	return len(checkValue(def, data)) == 0
+}
+
+// resolveUnionBranch selects alternative of union for the next entry of path
+func resolveUnionBranch(def *Definition, data interface{}, entry string) *Definition {
+
+	if branch := def.Resolve(data); branch != nil {
+		return branch
+	}
+
+	// Find the first alternative which has the field
+	key, _ := parsePathEntry(entry)
+	for _, branch := range def.Types {
+		if branch.Type != TYPE_MAP || branch.Schema == nil {
+			continue
+		}
+
+		if _, ok := branch.Schema.Fields[key]; ok {
+			return branch
+		}
+	}
+
+	return nil
+}
+
+func getUnionValue(def *Definition, data interface{}) (interface{}, error) {
+
+	branch := def.Resolve(data)
+	if branch == nil {
+		return nil, ErrInvalidType
+	}
+
+	if branch.Type != TYPE_MAP {
+		return getValue(branch, data)
+	}
+
+	m, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, ErrInvalidType
+	}
+
+	return branch.Schema.normalizeUnionBranch(def, branch, "", m, nil), nil
+}
+
+// normalizeUnionBranch normalizes data with schema of the selected alternative
+func (s *Schema) normalizeUnionBranch(def *Definition, branch *Definition, path string, data map[string]interface{}, errs *[]*ConversionError) map[string]interface{} {
+
+	if errs == nil {
+		errs = &[]*ConversionError{}
+	}
+
+	result := s.normalize(branch.Schema, path, data, errs)
+
+	// Keep discriminator even if it is not defined in fields
+	if _, ok := result[def.Discriminator]; !ok && def.Discriminator != "" {
+		result[def.Discriminator] = data[def.Discriminator]
+	}
+
+	return result
+}
@@ -0,0 +1,187 @@
+package schemer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testUnionSource = `{
+	"value": {
+		"type": "union",
+		"types": [ "int", "float", "string" ]
+	},
+	"payload": {
+		"type": "union",
+		"discriminator": "kind",
+		"types": {
+			"click": {
+				"type": "map",
+				"fields": {
+					"x": { "type": "int" },
+					"y": { "type": "int" }
+				}
+			},
+			"view": {
+				"type": "map",
+				"fields": {
+					"page": { "type": "string" },
+					"duration": { "type": "float" }
+				}
+			}
+		}
+	}
+}`
+
+func TestUnionNormalize(t *testing.T) {
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(testUnionSource), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, TYPE_UNION, schema.Fields["payload"].Type)
+	assert.Equal(t, "kind", schema.Fields["payload"].Discriminator)
+	assert.Len(t, schema.Fields["value"].Types, 3)
+
+	result := schema.Normalize(map[string]interface{}{
+		"value": float64(1.5),
+		"payload": map[string]interface{}{
+			"kind":    "click",
+			"x":       float64(10),
+			"y":       "20",
+			"ignored": true,
+		},
+	})
+
+	assert.Equal(t, float64(1.5), result["value"])
+	assert.Equal(t, map[string]interface{}{
+		"kind": "click",
+		"x":    int64(10),
+		"y":    int64(20),
+	}, result["payload"])
+
+	result = schema.Normalize(map[string]interface{}{
+		"value": float64(3),
+		"payload": map[string]interface{}{
+			"kind":     "view",
+			"page":     "home",
+			"duration": "1.5",
+		},
+	})
+
+	assert.Equal(t, int64(3), result["value"])
+	assert.Equal(t, map[string]interface{}{
+		"kind":     "view",
+		"page":     "home",
+		"duration": float64(1.5),
+	}, result["payload"])
+
+	// Unknown alternative
+	result = schema.Normalize(map[string]interface{}{
+		"value": map[string]interface{}{},
+		"payload": map[string]interface{}{
+			"kind": "scroll",
+		},
+	})
+
+	assert.Nil(t, result["value"])
+	assert.Nil(t, result["payload"])
+}
+
+func TestUnionGetDefinition(t *testing.T) {
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(testUnionSource), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// Without data, the first alternative which has the field is used
+	def := schema.GetDefinition("payload.page")
+	if assert.NotNil(t, def) {
+		assert.Equal(t, TYPE_STRING, def.Type)
+	}
+
+	assert.Nil(t, schema.GetDefinition("payload.unknown"))
+
+	record := schema.Scan(map[string]interface{}{
+		"value": "Brobridge",
+		"payload": map[string]interface{}{
+			"kind": "view",
+			"page": "home",
+		},
+	})
+
+	v := record.GetValue("value")
+	if assert.NotNil(t, v) {
+		assert.Equal(t, TYPE_STRING, v.Definition.Type)
+		assert.Equal(t, "Brobridge", v.Data)
+	}
+
+	v = record.GetValue("payload.page")
+	if assert.NotNil(t, v) {
+		assert.Equal(t, "home", v.Data)
+	}
+
+	v = record.GetValue("payload")
+	if assert.NotNil(t, v) {
+		assert.Equal(t, schema.Fields["payload"].Mapping["view"], v.Definition)
+	}
+}
+
+func TestUnionValidate(t *testing.T) {
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(testUnionSource), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	violations := schema.Validate(map[string]interface{}{
+		"value": []interface{}{},
+		"payload": map[string]interface{}{
+			"kind": "click",
+			"x":    "left",
+		},
+	})
+	if !assert.Len(t, violations, 2) {
+		return
+	}
+
+	assert.Equal(t, "payload.x", violations[0].Path)
+	assert.Equal(t, VIOLATION_UNPARSABLE, violations[0].Reason)
+	assert.Equal(t, "value", violations[1].Path)
+	assert.Equal(t, VIOLATION_INVALID_TYPE, violations[1].Reason)
+}
+
+func TestUnionMarshalJSON(t *testing.T) {
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(testUnionSource), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	data, err := schema.MarshalJSON()
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	restored := NewSchema()
+	err = UnmarshalJSON(data, restored)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Nil(t, SchemaDiff(schema, restored))
+	assert.Equal(t, TYPE_FLOAT64, restored.Fields["payload"].Mapping["view"].Schema.Fields["duration"].Type)
+
+	// Invalid definitions
+	err = UnmarshalJSON([]byte(`{ "value": { "type": "union" } }`), NewSchema())
+	assert.Equal(t, ErrInvalidUnionDefinition, err)
+
+	err = UnmarshalJSON([]byte(`{ "value": { "type": "union", "discriminator": "kind", "types": { "a": "int" } } }`), NewSchema())
+	assert.Equal(t, ErrInvalidUnionDefinition, err)
+}
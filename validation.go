@@ -0,0 +1,259 @@
+package schemer
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/BrobridgeOrg/schemer/types"
+)
+
+type ViolationReason string
+
+const (
+	VIOLATION_NOT_NULL     ViolationReason = "not_null"
+	VIOLATION_INVALID_TYPE ViolationReason = "invalid_type"
+	VIOLATION_UNPARSABLE   ViolationReason = "unparsable"
+	VIOLATION_OUT_OF_RANGE ViolationReason = "out_of_range"
+	VIOLATION_LENGTH       ViolationReason = "length"
+	VIOLATION_PATTERN      ViolationReason = "pattern"
+	VIOLATION_ENUM         ViolationReason = "enum"
+	VIOLATION_MULTIPLE_OF  ViolationReason = "multiple_of"
+	VIOLATION_ITEMS        ViolationReason = "items"
+	VIOLATION_PRECISION    ViolationReason = "precision_loss"
+)
+
+var conversionViolations = map[error]ViolationReason{
+	ErrInvalidType:        VIOLATION_INVALID_TYPE,
+	ErrUnparsable:         VIOLATION_UNPARSABLE,
+	ErrOverflow:           VIOLATION_OUT_OF_RANGE,
+	ErrPrecisionLoss:      VIOLATION_PRECISION,
+	ErrNegativeToUnsigned: VIOLATION_OUT_OF_RANGE,
+	ErrNestedToString:     VIOLATION_INVALID_TYPE,
+}
+
+var constraintViolations = map[error]ViolationReason{
+	types.ErrOutOfRange:      VIOLATION_OUT_OF_RANGE,
+	types.ErrInvalidLength:   VIOLATION_LENGTH,
+	types.ErrPatternMismatch: VIOLATION_PATTERN,
+	types.ErrNotInEnum:       VIOLATION_ENUM,
+	types.ErrNotMultipleOf:   VIOLATION_MULTIPLE_OF,
+	types.ErrInvalidItems:    VIOLATION_ITEMS,
+}
+
+// Violation describes a value which doesn't match its definition
+type Violation struct {
+	Path     string
+	Expected ValueType
+	Actual   string
+	Reason   ViolationReason
+}
+
+func NewViolation(path string, def *Definition, data interface{}, reason ViolationReason) *Violation {
+	return &Violation{
+		Path:     path,
+		Expected: def.Type,
+		Actual:   fmt.Sprintf("%T", data),
+		Reason:   reason,
+	}
+}
+
+func (v *Violation) Error() string {
+	return fmt.Sprintf("%s: %s (expected %s, got %s)", v.Path, v.Reason, v.Expected, v.Actual)
+}
+
+func joinPath(parent string, key string) string {
+
+	if len(parent) == 0 {
+		return key
+	}
+
+	return parent + "." + key
+}
+
+func sortedFieldNames(fields map[string]*Definition) []string {
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Validate checks data against schema and returns all violations. It returns nil if data is valid.
+func (s *Schema) Validate(data map[string]interface{}) []*Violation {
+
+	violations := s.validate(s, "", data)
+
+	// Values set by path
+	keys := make([]string, 0)
+	for key := range data {
+
+		// Skip internal fields
+		if len(key) == 0 || key[0] == '$' {
+			continue
+		}
+
+		if !strings.Contains(key, ".") {
+			continue
+		}
+
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+
+		def := s.GetDefinition(key)
+		if def == nil {
+			continue
+		}
+
+		violations = append(violations, s.validateValue(def, key, data[key])...)
+	}
+
+	return violations
+}
+
+func (s *Schema) validate(schema *Schema, path string, data map[string]interface{}) []*Violation {
+
+	var violations []*Violation
+
+	for _, fieldName := range sortedFieldNames(schema.Fields) {
+
+		// Skip internal fields
+		if fieldName[0] == '$' {
+			continue
+		}
+
+		def := schema.Fields[fieldName]
+
+		val, ok := data[fieldName]
+		if (!ok || val == nil) && def.Default != nil {
+			// Default value will be applied
+			continue
+		}
+
+		if !ok {
+			if def.NotNull {
+				violations = append(violations, NewViolation(joinPath(path, fieldName), def, nil, VIOLATION_NOT_NULL))
+			}
+
+			continue
+		}
+
+		violations = append(violations, s.validateValue(def, joinPath(path, fieldName), val)...)
+	}
+
+	return violations
+}
+
+func (s *Schema) validateValue(def *Definition, path string, data interface{}) []*Violation {
+
+	if data == nil {
+		if def.NotNull {
+			return []*Violation{
+				NewViolation(path, def, data, VIOLATION_NOT_NULL),
+			}
+		}
+
+		return nil
+	}
+
+	v := getStandardValue(data)
+
+	switch def.Type {
+	case TYPE_UNION:
+
+		branch := def.Resolve(v)
+		if branch == nil {
+			return []*Violation{
+				NewViolation(path, def, data, VIOLATION_INVALID_TYPE),
+			}
+		}
+
+		return s.validateValue(branch, path, data)
+
+	case TYPE_MAP:
+
+		d, ok := v.(map[string]interface{})
+		if !ok {
+			return []*Violation{
+				NewViolation(path, def, data, VIOLATION_INVALID_TYPE),
+			}
+		}
+
+		return s.validate(def.Schema, path, d)
+
+	case TYPE_ARRAY:
+
+		rv := reflect.ValueOf(v)
+		if _, ok := v.([]byte); ok || (rv.Kind() != reflect.Array && rv.Kind() != reflect.Slice) {
+			return []*Violation{
+				NewViolation(path, def, data, VIOLATION_INVALID_TYPE),
+			}
+		}
+
+		var violations []*Violation
+		if def.Constraint != nil {
+			err := def.Constraint.CheckItems(rv.Len())
+			if err != nil {
+				violations = append(violations, NewViolation(path, def, data, constraintViolations[err]))
+			}
+		}
+
+		if def.Subtype == nil {
+			return violations
+		}
+
+		for i := 0; i < rv.Len(); i++ {
+			elementPath := fmt.Sprintf("%s[%d]", path, i)
+			violations = append(violations, s.validateValue(def.Subtype, elementPath, rv.Index(i).Interface())...)
+		}
+
+		return violations
+	}
+
+	_, err := getValue(def, v)
+	if reason := getViolationReason(err); len(reason) > 0 {
+		return []*Violation{
+			NewViolation(path, def, data, reason),
+		}
+	}
+
+	return nil
+}
+
+// getViolationReason returns the reason of error from conversion or constraints
+func getViolationReason(err error) ViolationReason {
+
+	if err == nil {
+		return ""
+	}
+
+	// Error of nested value
+	if e, ok := err.(*ConversionError); ok {
+		err = e.Err
+	}
+
+	if reason, ok := conversionViolations[err]; ok {
+		return reason
+	}
+
+	if reason, ok := constraintViolations[err]; ok {
+		return reason
+	}
+
+	return VIOLATION_INVALID_TYPE
+}
+
+// checkValue returns the reason if value cannot be converted to the type of definition
+func checkValue(def *Definition, data interface{}) ViolationReason {
+	_, err := convertValue(def, data)
+	return getViolationReason(err)
+}
@@ -0,0 +1,241 @@
+package schemer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaValidate(t *testing.T) {
+
+	definition := `{
+	"name": { "type": "string", "notNull": true },
+	"age": { "type": "uint" },
+	"balance": { "type": "int" },
+	"score": { "type": "float" },
+	"enabled": { "type": "bool" },
+	"createdAt": { "type": "time" },
+	"attributes": {
+		"type": "map",
+		"fields": {
+			"title": { "type": "string" },
+			"level": { "type": "int", "notNull": true }
+		}
+	},
+	"tags": {
+		"type": "array",
+		"subtype": "int"
+	}
+}`
+
+	data := map[string]interface{}{
+		"age":       float64(-1),
+		"balance":   "abc",
+		"score":     "1.5",
+		"enabled":   "yes",
+		"createdAt": "not a time",
+		"attributes": map[string]interface{}{
+			"title": map[string]interface{}{},
+		},
+		"tags": []interface{}{
+			float64(1),
+			"two",
+		},
+	}
+
+	// Initializing schema
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(definition), schema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	violations := schema.Validate(data)
+	if !assert.Len(t, violations, 8) {
+		return
+	}
+
+	// Violations are sorted by path
+	assert.Equal(t, "age", violations[0].Path)
+	assert.Equal(t, VIOLATION_OUT_OF_RANGE, violations[0].Reason)
+	assert.Equal(t, TYPE_UINT64, violations[0].Expected)
+	assert.Equal(t, "float64", violations[0].Actual)
+
+	assert.Equal(t, "attributes.level", violations[1].Path)
+	assert.Equal(t, VIOLATION_NOT_NULL, violations[1].Reason)
+
+	assert.Equal(t, "attributes.title", violations[2].Path)
+	assert.Equal(t, VIOLATION_INVALID_TYPE, violations[2].Reason)
+
+	assert.Equal(t, "balance", violations[3].Path)
+	assert.Equal(t, VIOLATION_UNPARSABLE, violations[3].Reason)
+	assert.Equal(t, "string", violations[3].Actual)
+
+	assert.Equal(t, "createdAt", violations[4].Path)
+	assert.Equal(t, VIOLATION_UNPARSABLE, violations[4].Reason)
+
+	assert.Equal(t, "enabled", violations[5].Path)
+	assert.Equal(t, VIOLATION_UNPARSABLE, violations[5].Reason)
+
+	assert.Equal(t, "name", violations[6].Path)
+	assert.Equal(t, VIOLATION_NOT_NULL, violations[6].Reason)
+
+	assert.Equal(t, "tags[1]", violations[7].Path)
+	assert.Equal(t, TYPE_INT64, violations[7].Expected)
+	assert.Equal(t, VIOLATION_UNPARSABLE, violations[7].Reason)
+}
+
+func TestSchemaValidate_Valid(t *testing.T) {
+
+	definition := `{
+	"name": { "type": "string", "notNull": true },
+	"createdAt": { "type": "time" },
+	"attachments": {
+		"type": "array",
+		"subtype": "map",
+		"fields": {
+			"filename": { "type": "string" }
+		}
+	}
+}`
+
+	data := map[string]interface{}{
+		"$internal": "InternalValue",
+		"name":      "Fred",
+		"createdAt": "2020-07-19T18:16:08.000001Z",
+		"attachments": []interface{}{
+			map[string]interface{}{
+				"filename": "file1.txt",
+			},
+		},
+		"attachments.0.filename": "file2.txt",
+	}
+
+	// Initializing schema
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(definition), schema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Nil(t, schema.Validate(data))
+}
+
+func TestSchemaValidate_Constraints(t *testing.T) {
+
+	definition := `{
+	"age": { "type": "int", "minimum": 0, "maximum": 150 },
+	"score": { "type": "float", "multipleOf": 0.5 },
+	"name": { "type": "string", "minLength": 2, "maxLength": 8 },
+	"code": { "type": "string", "pattern": "^[A-Z]{3}$" },
+	"status": { "type": "string", "enum": [ "active", "inactive" ] },
+	"tags": {
+		"type": "array",
+		"subtype": "string",
+		"minItems": 1,
+		"maxItems": 2
+	}
+}`
+
+	data := map[string]interface{}{
+		"age":    float64(200),
+		"score":  float64(1.2),
+		"name":   "B",
+		"code":   "abc",
+		"status": "deleted",
+		"tags":   []interface{}{"a", "b", "c"},
+	}
+
+	// Initializing schema
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(definition), schema)
+	if err != nil {
+		t.Error(err)
+	}
+
+	violations := schema.Validate(data)
+	if !assert.Len(t, violations, 6) {
+		return
+	}
+
+	assert.Equal(t, "age", violations[0].Path)
+	assert.Equal(t, VIOLATION_OUT_OF_RANGE, violations[0].Reason)
+	assert.Equal(t, "code", violations[1].Path)
+	assert.Equal(t, VIOLATION_PATTERN, violations[1].Reason)
+	assert.Equal(t, "name", violations[2].Path)
+	assert.Equal(t, VIOLATION_LENGTH, violations[2].Reason)
+	assert.Equal(t, "score", violations[3].Path)
+	assert.Equal(t, VIOLATION_MULTIPLE_OF, violations[3].Reason)
+	assert.Equal(t, "status", violations[4].Path)
+	assert.Equal(t, VIOLATION_ENUM, violations[4].Reason)
+	assert.Equal(t, "tags", violations[5].Path)
+	assert.Equal(t, VIOLATION_ITEMS, violations[5].Reason)
+
+	// Valid values
+	assert.Nil(t, schema.Validate(map[string]interface{}{
+		"age":    float64(30),
+		"score":  float64(1.5),
+		"name":   "Bob",
+		"code":   "ABC",
+		"status": "active",
+		"tags":   []interface{}{"a"},
+	}))
+}
+
+func TestSchemaValidate_MatchesConversion(t *testing.T) {
+
+	definition := `{
+	"int": { "type": "int" },
+	"uint": { "type": "uint" },
+	"float": { "type": "float" },
+	"bool": { "type": "bool" },
+	"string": { "type": "string" },
+	"time": { "type": "time" },
+	"decimal": { "type": "decimal", "precision": 5, "scale": 2 },
+	"uuid": { "type": "uuid" },
+	"date": { "type": "date" },
+	"timeofday": { "type": "timeofday" },
+	"duration": { "type": "duration" },
+	"enum": { "type": "enum", "symbols": [ "A", "B" ] },
+	"binary": { "type": "binary", "encoding": "base64" },
+	"int8": { "type": "int8", "maximum": 10 }
+}`
+
+	schema := NewSchema()
+	err := UnmarshalJSON([]byte(definition), schema)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	values := []interface{}{
+		int64(-1),
+		uint64(18446744073709551615),
+		float64(1.5),
+		float64(100),
+		true,
+		"",
+		"abc",
+		"10",
+		"2020-07-19",
+		"1h",
+		"A",
+		"QUJD",
+		[]byte("ABC"),
+		map[string]interface{}{},
+		[]interface{}{},
+	}
+
+	// Validation reports violation if and only if conversion fails
+	for name := range schema.Fields {
+		for _, v := range values {
+
+			data := map[string]interface{}{
+				name: v,
+			}
+
+			_, errs := schema.NormalizeWithErrors(data)
+			violations := schema.Validate(data)
+			assert.Equal(t, len(errs), len(violations), "%s: %#v", name, v)
+		}
+	}
+}
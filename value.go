@@ -3,30 +3,73 @@ package schemer
 type ValueType int32
 
 const (
-	TYPE_BOOLEAN ValueType = 0
-	TYPE_BINARY  ValueType = 1
-	TYPE_STRING  ValueType = 2
-	TYPE_UINT64  ValueType = 3
-	TYPE_INT64   ValueType = 4
-	TYPE_FLOAT64 ValueType = 5
-	TYPE_ARRAY   ValueType = 6
-	TYPE_MAP     ValueType = 7
-	TYPE_TIME    ValueType = 8
-	TYPE_NULL    ValueType = 9
-	TYPE_ANY     ValueType = 10
+	TYPE_BOOLEAN   ValueType = 0
+	TYPE_BINARY    ValueType = 1
+	TYPE_STRING    ValueType = 2
+	TYPE_UINT64    ValueType = 3
+	TYPE_INT64     ValueType = 4
+	TYPE_FLOAT64   ValueType = 5
+	TYPE_ARRAY     ValueType = 6
+	TYPE_MAP       ValueType = 7
+	TYPE_TIME      ValueType = 8
+	TYPE_NULL      ValueType = 9
+	TYPE_ANY       ValueType = 10
+	TYPE_DECIMAL   ValueType = 11
+	TYPE_UUID      ValueType = 12
+	TYPE_DATE      ValueType = 13
+	TYPE_TIMEOFDAY ValueType = 14
+	TYPE_DURATION  ValueType = 15
+	TYPE_ENUM      ValueType = 16
+	TYPE_UNION     ValueType = 17
+	TYPE_INT8      ValueType = 18
+	TYPE_INT16     ValueType = 19
+	TYPE_INT32     ValueType = 20
+	TYPE_UINT8     ValueType = 21
+	TYPE_UINT16    ValueType = 22
+	TYPE_UINT32    ValueType = 23
+	TYPE_FLOAT32   ValueType = 24
 )
 
 var ValueTypes = map[string]ValueType{
-	"string": TYPE_STRING,
-	"binary": TYPE_BINARY,
-	"int":    TYPE_INT64,
-	"uint":   TYPE_UINT64,
-	"float":  TYPE_FLOAT64,
-	"bool":   TYPE_BOOLEAN,
-	"time":   TYPE_TIME,
-	"array":  TYPE_ARRAY,
-	"map":    TYPE_MAP,
-	"any":    TYPE_ANY,
+	"string":    TYPE_STRING,
+	"binary":    TYPE_BINARY,
+	"int":       TYPE_INT64,
+	"uint":      TYPE_UINT64,
+	"float":     TYPE_FLOAT64,
+	"bool":      TYPE_BOOLEAN,
+	"time":      TYPE_TIME,
+	"array":     TYPE_ARRAY,
+	"map":       TYPE_MAP,
+	"any":       TYPE_ANY,
+	"decimal":   TYPE_DECIMAL,
+	"uuid":      TYPE_UUID,
+	"date":      TYPE_DATE,
+	"timeofday": TYPE_TIMEOFDAY,
+	"duration":  TYPE_DURATION,
+	"enum":      TYPE_ENUM,
+	"union":     TYPE_UNION,
+	"int8":      TYPE_INT8,
+	"int16":     TYPE_INT16,
+	"int32":     TYPE_INT32,
+	"uint8":     TYPE_UINT8,
+	"uint16":    TYPE_UINT16,
+	"uint32":    TYPE_UINT32,
+	"float32":   TYPE_FLOAT32,
+}
+
+func (vt ValueType) String() string {
+
+	for name, t := range ValueTypes {
+		if t == vt {
+			return name
+		}
+	}
+
+	if vt == TYPE_NULL {
+		return "null"
+	}
+
+	return "unknown"
 }
 
 type Value struct {